@@ -1,8 +1,11 @@
 package main
 
 import (
+	"context"
 	"examples/ch1/fetch"
+	"examples/ch8/future"
 	"fmt"
+	"net/http"
 	"net/url"
 )
 
@@ -12,36 +15,41 @@ func main() {
 		"https://pkg.go.dev/golang.org/x",
 	}
 
-	urls := make([]*url.URL, len(urlStrings))
-	parseErrs := make([]error, len(urlStrings))
-
+	results := make([]*future.Future[[]string], len(urlStrings))
 	for i, urlString := range urlStrings {
-		urls[i], parseErrs[i] = url.Parse(urlString)
-	}
-
-	responses, networkErrs := fetch.Fetch(urls)
-
-	results := make([][]string, len(urlStrings))
-	parseLinkErrs := make([]error, len(urlStrings))
-
-	for i, response := range responses {
-		results[i], parseLinkErrs[i] = fetch.ParseHyperLinks(response)
+		parsed := future.Go(func() (*url.URL, error) {
+			u, err := url.Parse(urlString)
+			if err != nil {
+				return nil, fmt.Errorf("error while checking URL validity: %+v", err)
+			}
+			return u, nil
+		})
+		response := future.Then(parsed, func(u *url.URL) (*http.Response, error) {
+			responses, errs := fetch.Fetch([]*url.URL{u})
+			if errs[0] != nil {
+				return nil, fmt.Errorf("error while fetching HTTP response: %+v", errs[0])
+			}
+			return responses[0], nil
+		})
+		results[i] = future.Then(response, func(resp *http.Response) ([]string, error) {
+			defer resp.Body.Close()
+			links, err := fetch.ParseHyperLinks(resp)
+			if err != nil {
+				return links, fmt.Errorf("error while parsing hyperlinks in response: %+v", err)
+			}
+			return links, nil
+		})
 	}
 
-	for i := range len(urlStrings) {
+	for i, result := range results {
 		fmt.Printf("link: %s\n", urlStrings[i])
-		if parseErrs[i] != nil {
-			fmt.Printf("error while checking URL validity: %+v\n", parseErrs[i])
-		}
-		if networkErrs[i] != nil {
-			fmt.Printf("error while fetching HTTP response: %+v\n", networkErrs[i])
-		}
-		if parseLinkErrs[i] != nil {
-			fmt.Printf("error while parsing hyperlinks in response: %+v\n", parseLinkErrs[i])
+		links, err := result.Await(context.Background())
+		if err != nil {
+			fmt.Println(err)
 		}
-		if len(results[i]) > 0 {
+		if len(links) > 0 {
 			fmt.Printf("hyperlinks in the page %s\n", urlStrings[i])
-			for i, link := range results[i] {
+			for i, link := range links {
 				fmt.Printf("link %d %s\n", i, link)
 			}
 		}
@@ -2,7 +2,9 @@ package readline
 
 import (
 	"bufio"
+	"examples/ch2/custom_iter"
 	"io"
+	"slices"
 )
 
 func FindDuplicateLines(reader io.Reader) (result []string, err error) {
@@ -11,26 +13,22 @@ func FindDuplicateLines(reader io.Reader) (result []string, err error) {
 			err = nil
 		}
 	}()
-	m := make(map[string]uint)
 	r := bufio.NewReader(reader)
-	var buf []byte
-	for {
-		buf, err = r.ReadBytes('\n')
-		if len(buf) > 0 && buf[len(buf)-1] == '\n' {
-			buf = buf[:len(buf)-1]
-		}
-		if len(buf) > 0 && buf[len(buf)-1] == '\r' {
-			buf = buf[:len(buf)-1]
-		}
-		m[string(buf)]++
-		if err != nil {
-			break
-		}
-	}
-	for k, v := range m {
-		if v > 1 {
-			result = append(result, k)
+	lines := func(yield func(string) bool) {
+		var buf []byte
+		for {
+			buf, err = r.ReadBytes('\n')
+			if len(buf) > 0 && buf[len(buf)-1] == '\n' {
+				buf = buf[:len(buf)-1]
+			}
+			if len(buf) > 0 && buf[len(buf)-1] == '\r' {
+				buf = buf[:len(buf)-1]
+			}
+			if !yield(string(buf)) || err != nil {
+				return
+			}
 		}
 	}
+	result = slices.Collect(custom_iter.Duplicates(lines))
 	return
 }
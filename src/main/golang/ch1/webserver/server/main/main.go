@@ -2,43 +2,43 @@ package main
 
 import (
 	"examples/ch1/animated_gif"
+	"examples/ch8/flow"
+	"examples/ch8/logctx"
 	"fmt"
 	"io"
-	"log"
+	"log/slog"
 	"net/http"
-	"sync"
+	"os"
 )
 
-var (
-	mu    sync.Mutex
-	count uint
-)
+var visitors = flow.NewStateFlow[uint](0)
 
 func main() {
+	slog.SetDefault(slog.New(logctx.NewHandler(slog.NewTextHandler(os.Stderr, nil))))
 	http.HandleFunc("/", homeHandler)
 	http.HandleFunc("/gif", gifHandler)
-	log.Fatal(http.ListenAndServe(":8080", nil))
+	http.HandleFunc("/visitors", visitorsHandler)
+	err := http.ListenAndServe(":8080", logctx.RequestID(http.DefaultServeMux))
+	slog.Error("server stopped", "err", err)
+	os.Exit(1)
 }
 
 func homeHandler(writer http.ResponseWriter, request *http.Request) {
-	mu.Lock()
-	defer mu.Unlock()
 	_, err := fmt.Fprintf(writer, "URL Path: %q\n", request.URL.String())
 	if err != nil {
-		log.Printf("error writing response: %+v\n", err)
+		slog.ErrorContext(request.Context(), "error writing response", "err", err)
 	}
 	buf, err := io.ReadAll(request.Body)
 	if err != nil {
-		log.Printf("error reading request body: %+v\n", err)
+		slog.ErrorContext(request.Context(), "error reading request body", "err", err)
 	}
-	_, err = fmt.Fprintf(writer, "%d visitors before you\n", count)
-	count++
+	_, err = fmt.Fprintf(writer, "%d visitors before you\n", visitors.Update(func(n uint) uint { return n + 1 })-1)
 	if err != nil {
-		log.Printf("error writing count response: %+v\n", err)
+		slog.ErrorContext(request.Context(), "error writing count response", "err", err)
 	}
 	_, err = fmt.Fprintf(writer, "Echo: %q\n", buf)
 	if err != nil {
-		log.Printf("error writing response: %+v\n", err)
+		slog.ErrorContext(request.Context(), "error writing response", "err", err)
 	}
 }
 
@@ -51,6 +51,26 @@ func gifHandler(writer http.ResponseWriter, request *http.Request) {
 		animated_gif.LissajousFigure{},
 	)
 	if err != nil {
-		log.Printf("error making gif: %+v\n", err)
+		slog.ErrorContext(request.Context(), "error making gif", "err", err)
+	}
+}
+
+// visitorsHandler streams the visitor count to the client, one line per change, until the client disconnects.
+func visitorsHandler(writer http.ResponseWriter, request *http.Request) {
+	flusher, ok := writer.(http.Flusher)
+	if !ok {
+		http.Error(writer, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+	writer.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	err := visitors.Flow().Collect(request.Context(), func(n uint) error {
+		if _, err := fmt.Fprintf(writer, "%d visitors\n", n); err != nil {
+			return err
+		}
+		flusher.Flush()
+		return nil
+	})
+	if err != nil && request.Context().Err() == nil {
+		slog.ErrorContext(request.Context(), "error streaming visitor count", "err", err)
 	}
 }
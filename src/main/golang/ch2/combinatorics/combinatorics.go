@@ -0,0 +1,214 @@
+// Package combinatorics is my Golang implementation of the combinatoric iterators in Python itertools.
+// Every sequence yields in lexicographic order of the pool indices.
+// The yielded slice is a buffer reused across iterations, so clone it if it has to outlive the loop body.
+// The *Seq variants collect the input sequence into a pool each time they are ranged over.
+package combinatorics
+
+import (
+	"iter"
+	"slices"
+)
+
+func CartesianProduct[T any](pools ...[]T) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		for _, pool := range pools {
+			if len(pool) == 0 {
+				return
+			}
+		}
+		indices := make([]int, len(pools))
+		result := make([]T, len(pools))
+		for i, pool := range pools {
+			result[i] = pool[0]
+		}
+		for {
+			if !yield(result) {
+				return
+			}
+			i := len(pools) - 1
+			for ; i >= 0; i-- {
+				indices[i]++
+				if indices[i] < len(pools[i]) {
+					result[i] = pools[i][indices[i]]
+					break
+				}
+				indices[i] = 0
+				result[i] = pools[i][0]
+			}
+			if i < 0 {
+				return
+			}
+		}
+	}
+}
+
+func CartesianProductSeq[T any](seqs ...iter.Seq[T]) iter.Seq[[]T] {
+	return lazily(func() iter.Seq[[]T] {
+		pools := make([][]T, len(seqs))
+		for i, seq := range seqs {
+			pools[i] = slices.Collect(seq)
+		}
+		return CartesianProduct(pools...)
+	})
+}
+
+// lazily defers building the sequence, and so collecting the input, until the result is ranged over.
+func lazily[T any](build func() iter.Seq[[]T]) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		build()(yield)
+	}
+}
+
+func Permutations[T any](pool []T, k uint) iter.Seq[[]T] {
+	n := len(pool)
+	r := int(k)
+	return func(yield func([]T) bool) {
+		if r > n {
+			return
+		}
+		indices := make([]int, n)
+		for i := range indices {
+			indices[i] = i
+		}
+		cycles := make([]int, r)
+		for i := range cycles {
+			cycles[i] = n - i
+		}
+		result := make([]T, r)
+		fill := func() {
+			for i := range result {
+				result[i] = pool[indices[i]]
+			}
+		}
+		fill()
+		if !yield(result) {
+			return
+		}
+		for {
+			i := r - 1
+			for ; i >= 0; i-- {
+				cycles[i]--
+				if cycles[i] == 0 {
+					first := indices[i]
+					copy(indices[i:], indices[i+1:])
+					indices[n-1] = first
+					cycles[i] = n - i
+					continue
+				}
+				j := n - cycles[i]
+				indices[i], indices[j] = indices[j], indices[i]
+				fill()
+				if !yield(result) {
+					return
+				}
+				break
+			}
+			if i < 0 {
+				return
+			}
+		}
+	}
+}
+
+func PermutationsSeq[T any](it iter.Seq[T], k uint) iter.Seq[[]T] {
+	return lazily(func() iter.Seq[[]T] {
+		return Permutations(slices.Collect(it), k)
+	})
+}
+
+func Combinations[T any](pool []T, k uint) iter.Seq[[]T] {
+	n := len(pool)
+	r := int(k)
+	return func(yield func([]T) bool) {
+		if r > n {
+			return
+		}
+		indices := make([]int, r)
+		result := make([]T, r)
+		for i := range indices {
+			indices[i] = i
+			result[i] = pool[i]
+		}
+		for {
+			if !yield(result) {
+				return
+			}
+			i := r - 1
+			for i >= 0 && indices[i] == i+n-r {
+				i--
+			}
+			if i < 0 {
+				return
+			}
+			indices[i]++
+			result[i] = pool[indices[i]]
+			for j := i + 1; j < r; j++ {
+				indices[j] = indices[j-1] + 1
+				result[j] = pool[indices[j]]
+			}
+		}
+	}
+}
+
+func CombinationsSeq[T any](it iter.Seq[T], k uint) iter.Seq[[]T] {
+	return lazily(func() iter.Seq[[]T] {
+		return Combinations(slices.Collect(it), k)
+	})
+}
+
+func CombinationsWithReplacement[T any](pool []T, k uint) iter.Seq[[]T] {
+	n := len(pool)
+	r := int(k)
+	return func(yield func([]T) bool) {
+		if n == 0 && r > 0 {
+			return
+		}
+		indices := make([]int, r)
+		result := make([]T, r)
+		for i := range result {
+			result[i] = pool[0]
+		}
+		for {
+			if !yield(result) {
+				return
+			}
+			i := r - 1
+			for i >= 0 && indices[i] == n-1 {
+				i--
+			}
+			if i < 0 {
+				return
+			}
+			next := indices[i] + 1
+			for j := i; j < r; j++ {
+				indices[j] = next
+				result[j] = pool[next]
+			}
+		}
+	}
+}
+
+func CombinationsWithReplacementSeq[T any](it iter.Seq[T], k uint) iter.Seq[[]T] {
+	return lazily(func() iter.Seq[[]T] {
+		return CombinationsWithReplacement(slices.Collect(it), k)
+	})
+}
+
+// Powerset yields every subset ordered by size, then lexicographically.
+func Powerset[T any](pool []T) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		for k := range uint(len(pool)) + 1 {
+			for subset := range Combinations(pool, k) {
+				if !yield(subset) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func PowersetSeq[T any](it iter.Seq[T]) iter.Seq[[]T] {
+	return lazily(func() iter.Seq[[]T] {
+		return Powerset(slices.Collect(it))
+	})
+}
@@ -0,0 +1,119 @@
+package combinatorics
+
+import (
+	"fmt"
+	"iter"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func collect(it func(yield func([]int) bool)) [][]int {
+	var result [][]int
+	for s := range it {
+		result = append(result, slices.Clone(s))
+	}
+	return result
+}
+
+func TestCartesianProduct(t *testing.T) {
+	tcs := []struct {
+		pools [][]int
+		want  [][]int
+	}{
+		{[][]int{{1, 2}, {3, 4}}, [][]int{{1, 3}, {1, 4}, {2, 3}, {2, 4}}},
+		{[][]int{{1}, {2, 3}, {4}}, [][]int{{1, 2, 4}, {1, 3, 4}}},
+		{[][]int{{1, 2}, {}}, nil},
+		{nil, [][]int{{}}},
+	}
+	for _, tc := range tcs {
+		t.Run(fmt.Sprintf("cartesian product %v", tc.pools), func(t *testing.T) {
+			got := collect(CartesianProduct(tc.pools...))
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v want %v\n", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestPermutations(t *testing.T) {
+	tcs := []struct {
+		pool []int
+		k    uint
+		want [][]int
+	}{
+		{[]int{1, 2, 3}, 2, [][]int{{1, 2}, {1, 3}, {2, 1}, {2, 3}, {3, 1}, {3, 2}}},
+		{[]int{1, 2, 3}, 3, [][]int{{1, 2, 3}, {1, 3, 2}, {2, 1, 3}, {2, 3, 1}, {3, 1, 2}, {3, 2, 1}}},
+		{[]int{1, 2}, 3, nil},
+		{[]int{1, 2}, 0, [][]int{{}}},
+	}
+	for _, tc := range tcs {
+		t.Run(fmt.Sprintf("permutations %v %v", tc.pool, tc.k), func(t *testing.T) {
+			got := collect(Permutations(tc.pool, tc.k))
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v want %v\n", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCombinations(t *testing.T) {
+	tcs := []struct {
+		pool []int
+		k    uint
+		want [][]int
+	}{
+		{[]int{1, 2, 3, 4}, 2, [][]int{{1, 2}, {1, 3}, {1, 4}, {2, 3}, {2, 4}, {3, 4}}},
+		{[]int{1, 2, 3}, 3, [][]int{{1, 2, 3}}},
+		{[]int{1, 2}, 3, nil},
+	}
+	for _, tc := range tcs {
+		t.Run(fmt.Sprintf("combinations %v %v", tc.pool, tc.k), func(t *testing.T) {
+			got := collect(CombinationsSeq(slices.Values(tc.pool), tc.k))
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v want %v\n", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCombinationsWithReplacement(t *testing.T) {
+	got := collect(CombinationsWithReplacement([]int{1, 2, 3}, 2))
+	want := [][]int{{1, 1}, {1, 2}, {1, 3}, {2, 2}, {2, 3}, {3, 3}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v want %v\n", got, want)
+	}
+}
+
+func TestPowerset(t *testing.T) {
+	got := collect(Powerset([]int{1, 2, 3}))
+	want := [][]int{{}, {1}, {2}, {3}, {1, 2}, {1, 3}, {2, 3}, {1, 2, 3}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v want %v\n", got, want)
+	}
+}
+
+func TestSeqVariantsAreLazy(t *testing.T) {
+	pulled := 0
+	source := func(yield func(int) bool) {
+		for _, i := range []int{1, 2} {
+			pulled++
+			if !yield(i) {
+				return
+			}
+		}
+	}
+	seqs := []iter.Seq[[]int]{
+		CartesianProductSeq(source, source),
+		PermutationsSeq(source, 2),
+		CombinationsSeq(source, 2),
+		CombinationsWithReplacementSeq(source, 2),
+		PowersetSeq(source),
+	}
+	if pulled != 0 {
+		t.Errorf("got %d elements pulled before ranging want %d\n", pulled, 0)
+	}
+	if got := collect(seqs[1]); !reflect.DeepEqual(got, [][]int{{1, 2}, {2, 1}}) || pulled != 2 {
+		t.Errorf("got %v after %d pulls want %v after %d pulls\n", got, pulled, [][]int{{1, 2}, {2, 1}}, 2)
+	}
+}
@@ -0,0 +1,116 @@
+package custom_iter
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"reflect"
+	"sync"
+)
+
+// FromChan yields every value received from ch until it is closed.
+func FromChan[T any](ch <-chan T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for t := range ch {
+			if !yield(t) {
+				return
+			}
+		}
+	}
+}
+
+// FromChanCtx is FromChan that also stops when ctx is done, even while blocked on ch.
+func FromChanCtx[T any](ctx context.Context, ch <-chan T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for {
+			select {
+			case t, ok := <-ch:
+				if !ok || !yield(t) {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// ToChan runs it on its own goroutine and sends the elements to the returned channel, which is closed at the end.
+// stop cancels the producer, waits for it to exit and reports why the channel was closed:
+// nil when it was drained or stopped by the caller, ctx.Err() when ctx was done, or the panic of the producer.
+// stop must be called once the consumer is done with the channel, even after it was drained.
+func ToChan[T any](ctx context.Context, it iter.Seq[T], bufSize uint) (ch <-chan T, stop func() error) {
+	out := make(chan T, bufSize)
+	inner, cancel := context.WithCancel(ctx)
+	finished := make(chan struct{})
+	var err error
+	go func() {
+		defer close(finished)
+		defer close(out)
+		defer func() {
+			if p := recover(); p != nil {
+				err = fmt.Errorf("ToChan producer panicked: %v", p)
+			}
+		}()
+		for t := range it {
+			select {
+			case out <- t:
+			case <-inner.Done():
+				err = ctx.Err()
+				return
+			}
+		}
+	}()
+	var once sync.Once
+	stop = func() error {
+		once.Do(func() {
+			cancel()
+			<-finished
+		})
+		return err
+	}
+	return out, stop
+}
+
+// SelectChans receives from every channel at once like a select statement with one case per channel,
+// and yields the index of the ready channel along with the value. Closed channels drop out of the select,
+// and the sequence ends when all of them are closed or ctx is done.
+func SelectChans[T any](ctx context.Context, chans ...<-chan T) iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		cases := make([]reflect.SelectCase, 0, len(chans)+1)
+		indices := make([]int, 0, len(chans))
+		cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ctx.Done())})
+		for i, ch := range chans {
+			cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ch)})
+			indices = append(indices, i)
+		}
+		for len(indices) > 0 {
+			chosen, value, ok := reflect.Select(cases)
+			if chosen == 0 {
+				return
+			}
+			if !ok {
+				cases = append(cases[:chosen], cases[chosen+1:]...)
+				indices = append(indices[:chosen-1], indices[chosen:]...)
+				continue
+			}
+			// Interface() returns a nil any for a nil interface element such as a nil error,
+			// so it is converted with the comma-ok form.
+			t, _ := value.Interface().(T)
+			if !yield(indices[chosen-1], t) {
+				return
+			}
+		}
+	}
+}
+
+// MergeChans is SelectChans without the channel indices.
+func MergeChans[T any](ctx context.Context, chans ...<-chan T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, t := range SelectChans(ctx, chans...) {
+			if !yield(t) {
+				return
+			}
+		}
+	}
+}
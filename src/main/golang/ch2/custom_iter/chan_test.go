@@ -0,0 +1,103 @@
+package custom_iter
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestFromChan(t *testing.T) {
+	ch := make(chan int, 3)
+	ch <- 1
+	ch <- 2
+	ch <- 3
+	close(ch)
+	if got := slices.Collect(FromChan(ch)); !reflect.DeepEqual(got, []int{1, 2, 3}) {
+		t.Errorf("got %v want %v\n", got, []int{1, 2, 3})
+	}
+}
+
+func TestFromChanCtx(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch := make(chan int, 1)
+	ch <- 1
+	var got []int
+	for i := range FromChanCtx(ctx, ch) {
+		got = append(got, i)
+		cancel()
+	}
+	if !reflect.DeepEqual(got, []int{1}) {
+		t.Errorf("got %v want %v\n", got, []int{1})
+	}
+}
+
+func TestToChan(t *testing.T) {
+	ch, stop := ToChan(context.Background(), slices.Values([]int{1, 2, 3}), 1)
+	got := slices.Collect(FromChan(ch))
+	if err := stop(); !reflect.DeepEqual(got, []int{1, 2, 3}) || err != nil {
+		t.Errorf("got %v %v want %v %v\n", got, err, []int{1, 2, 3}, nil)
+	}
+
+	ch, stop = ToChan(context.Background(), Repeat(1), 0)
+	<-ch
+	if err := stop(); err != nil {
+		t.Errorf("got %v want %v\n", err, nil)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch, stop = ToChan(ctx, Repeat(1), 0)
+	<-ch
+	cancel()
+	for range ch {
+	}
+	if err := stop(); !errors.Is(err, context.Canceled) {
+		t.Errorf("got %v want %v\n", err, context.Canceled)
+	}
+
+	ch, stop = ToChan(context.Background(), func(yield func(int) bool) { panic("boom") }, 0)
+	for range ch {
+	}
+	if err := stop(); err == nil || !strings.Contains(err.Error(), "boom") {
+		t.Errorf("got %v want panic error\n", err)
+	}
+}
+
+func TestSelectChans(t *testing.T) {
+	a, b := make(chan string, 2), make(chan string, 2)
+	a <- "a1"
+	a <- "a2"
+	b <- "b1"
+	close(a)
+	close(b)
+	var got []string
+	from := map[int]int{}
+	for i, s := range SelectChans(context.Background(), a, b) {
+		got = append(got, s)
+		from[i]++
+	}
+	slices.Sort(got)
+	if want := []string{"a1", "a2", "b1"}; !reflect.DeepEqual(got, want) || from[0] != 2 || from[1] != 1 {
+		t.Errorf("got %v %v want %v\n", got, from, want)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if got := Count(MergeChans(ctx, make(chan int))); got != 0 {
+		t.Errorf("got %v want %v\n", got, 0)
+	}
+}
+
+func TestSelectChansNilInterface(t *testing.T) {
+	errs := make(chan error, 2)
+	errs <- nil
+	errs <- errors.ErrUnsupported
+	close(errs)
+	got := slices.Collect(MergeChans(context.Background(), errs))
+	if want := []error{nil, errors.ErrUnsupported}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v want %v\n", got, want)
+	}
+}
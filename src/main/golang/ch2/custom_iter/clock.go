@@ -0,0 +1,191 @@
+package custom_iter
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock is the source of time of the time-based iterators, so that tests can swap in a ManualClock.
+type Clock interface {
+	Now() time.Time
+	// NewTimer returns a Timer firing once after d.
+	NewTimer(d time.Duration) Timer
+	// NewTicker returns a Timer firing every d, dropping ticks for a slow receiver.
+	NewTicker(d time.Duration) Timer
+}
+
+type Timer interface {
+	C() <-chan time.Time
+	// Stop prevents the timer from firing again, and reports whether it was still pending.
+	Stop() bool
+}
+
+// SystemClock is the Clock of the time package.
+var SystemClock Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) NewTimer(d time.Duration) Timer {
+	return systemTimer{time.NewTimer(d)}
+}
+
+func (systemClock) NewTicker(d time.Duration) Timer {
+	return systemTicker{time.NewTicker(d)}
+}
+
+type systemTimer struct {
+	t *time.Timer
+}
+
+func (t systemTimer) C() <-chan time.Time {
+	return t.t.C
+}
+
+func (t systemTimer) Stop() bool {
+	return t.t.Stop()
+}
+
+type systemTicker struct {
+	t *time.Ticker
+}
+
+func (t systemTicker) C() <-chan time.Time {
+	return t.t.C
+}
+
+func (t systemTicker) Stop() bool {
+	t.t.Stop()
+	return true
+}
+
+// ManualClock is a Clock that only moves when Advance is called.
+// Its timers deliver synchronously: Advance returns once every due tick was received or its timer stopped,
+// so Advance must not be called by the goroutine receiving from the timers.
+type ManualClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	timers  []*manualTimer
+	changed chan struct{}
+}
+
+func NewManualClock(start time.Time) *ManualClock {
+	return &ManualClock{now: start, changed: make(chan struct{})}
+}
+
+type manualTimer struct {
+	clock    *ManualClock
+	deadline time.Time
+	period   time.Duration
+	c        chan time.Time
+	cancel   chan struct{}
+	once     sync.Once
+}
+
+func (c *ManualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *ManualClock) NewTimer(d time.Duration) Timer {
+	return c.add(d, 0)
+}
+
+func (c *ManualClock) NewTicker(d time.Duration) Timer {
+	if d <= 0 {
+		panic("non-positive interval for NewTicker")
+	}
+	return c.add(d, d)
+}
+
+func (c *ManualClock) add(d, period time.Duration) *manualTimer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t := &manualTimer{
+		clock:    c,
+		deadline: c.now.Add(d),
+		period:   period,
+		c:        make(chan time.Time),
+		cancel:   make(chan struct{}),
+	}
+	c.timers = append(c.timers, t)
+	close(c.changed)
+	c.changed = make(chan struct{})
+	return t
+}
+
+func (t *manualTimer) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *manualTimer) Stop() bool {
+	t.once.Do(func() { close(t.cancel) })
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	for i, other := range t.clock.timers {
+		if other == t {
+			t.clock.timers = append(t.clock.timers[:i], t.clock.timers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Timers is the number of pending timers and tickers.
+func (c *ManualClock) Timers() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.timers)
+}
+
+// BlockUntilTimers waits until at least n timers or tickers are pending.
+func (c *ManualClock) BlockUntilTimers(n int) {
+	for {
+		c.mu.Lock()
+		pending, changed := len(c.timers), c.changed
+		c.mu.Unlock()
+		if pending >= n {
+			return
+		}
+		<-changed
+	}
+}
+
+// Advance moves the clock forward by d, firing due timers in deadline order at their own deadline.
+func (c *ManualClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	target := c.now.Add(d)
+	c.mu.Unlock()
+	for {
+		c.mu.Lock()
+		next := -1
+		for i, t := range c.timers {
+			if !t.deadline.After(target) && (next < 0 || t.deadline.Before(c.timers[next].deadline)) {
+				next = i
+			}
+		}
+		if next < 0 {
+			c.now = target
+			c.mu.Unlock()
+			return
+		}
+		t := c.timers[next]
+		c.now = t.deadline
+		if t.period > 0 {
+			t.deadline = t.deadline.Add(t.period)
+		} else {
+			c.timers = append(c.timers[:next], c.timers[next+1:]...)
+		}
+		now := c.now
+		c.mu.Unlock()
+
+		select {
+		case t.c <- now:
+		case <-t.cancel:
+		}
+	}
+}
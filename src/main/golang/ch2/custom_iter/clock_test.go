@@ -0,0 +1,42 @@
+package custom_iter
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestManualClock(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 0))
+	timer := clock.NewTimer(30 * time.Millisecond)
+	stopped := clock.NewTimer(20 * time.Millisecond)
+	ticker := clock.NewTicker(20 * time.Millisecond)
+	if !stopped.Stop() || stopped.Stop() {
+		t.Errorf("Stop got wrong pending state\n")
+	}
+
+	fired := make(chan []time.Duration)
+	go func() {
+		var got []time.Duration
+		for range 3 {
+			select {
+			case now := <-timer.C():
+				got = append(got, now.Sub(time.Unix(0, 0)))
+			case now := <-ticker.C():
+				got = append(got, -now.Sub(time.Unix(0, 0)))
+			}
+		}
+		fired <- got
+	}()
+	clock.Advance(45 * time.Millisecond)
+	want := []time.Duration{-20 * time.Millisecond, 30 * time.Millisecond, -40 * time.Millisecond}
+	if got := <-fired; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v want %v\n", got, want)
+	}
+	if got := clock.Now().Sub(time.Unix(0, 0)); got != 45*time.Millisecond {
+		t.Errorf("got %v want %v\n", got, 45*time.Millisecond)
+	}
+	if got := clock.Timers(); got != 1 {
+		t.Errorf("got %d pending timers want %d\n", got, 1)
+	}
+}
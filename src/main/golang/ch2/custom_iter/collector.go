@@ -0,0 +1,133 @@
+package custom_iter
+
+import (
+	"examples/ch2/custom_set"
+	"iter"
+	"slices"
+	"strings"
+)
+
+// Collector is the Golang counterpart of FromIterator + Extend in Rust.
+// New creates an empty container, Extend appends every element of the sequence and returns the container.
+type Collector[T, C any] interface {
+	New() C
+	Extend(C, iter.Seq[T]) C
+}
+
+// Collector2 is Collector for iter.Seq2 sources such as maps.
+type Collector2[K, V, C any] interface {
+	New() C
+	Extend(C, iter.Seq2[K, V]) C
+}
+
+type SliceCollector[T any] struct{}
+
+func (SliceCollector[T]) New() []T {
+	return nil
+}
+
+func (SliceCollector[T]) Extend(s []T, it iter.Seq[T]) []T {
+	return slices.AppendSeq(s, it)
+}
+
+type MapCollector[K comparable, V any] struct{}
+
+func (MapCollector[K, V]) New() map[K]V {
+	return make(map[K]V)
+}
+
+func (MapCollector[K, V]) Extend(m map[K]V, it iter.Seq2[K, V]) map[K]V {
+	for k, v := range it {
+		m[k] = v
+	}
+	return m
+}
+
+type SetCollector[T comparable] struct{}
+
+func (SetCollector[T]) New() *custom_set.Set[T] {
+	return custom_set.New[T]()
+}
+
+func (SetCollector[T]) Extend(set *custom_set.Set[T], it iter.Seq[T]) *custom_set.Set[T] {
+	for t := range it {
+		set.Add(t)
+	}
+	return set
+}
+
+type BuilderCollector struct{}
+
+func (BuilderCollector) New() *strings.Builder {
+	return new(strings.Builder)
+}
+
+func (BuilderCollector) Extend(builder *strings.Builder, it iter.Seq[string]) *strings.Builder {
+	for s := range it {
+		builder.WriteString(s)
+	}
+	return builder
+}
+
+// ChanCollector sends every element to Ch. The channel is never closed by the collector.
+// New returns Ch on every call, so Partition and UnzipInto with two ChanCollectors of the same channel send both
+// halves into it. Use one ChanCollector per channel to keep them apart.
+type ChanCollector[T any] struct {
+	Ch chan<- T
+}
+
+func (collector ChanCollector[T]) New() chan<- T {
+	return collector.Ch
+}
+
+func (ChanCollector[T]) Extend(ch chan<- T, it iter.Seq[T]) chan<- T {
+	for t := range it {
+		ch <- t
+	}
+	return ch
+}
+
+type CountCollector[T comparable] struct{}
+
+func (CountCollector[T]) New() map[T]uint {
+	return make(map[T]uint)
+}
+
+func (CountCollector[T]) Extend(m map[T]uint, it iter.Seq[T]) map[T]uint {
+	for t := range it {
+		m[t]++
+	}
+	return m
+}
+
+func Collect[T, C any, S ~func(func(T) bool)](it S, collector Collector[T, C]) C {
+	return collector.Extend(collector.New(), iter.Seq[T](it))
+}
+
+func Collect2[K, V, C any, S ~func(func(K, V) bool)](it S, collector Collector2[K, V, C]) C {
+	return collector.Extend(collector.New(), iter.Seq2[K, V](it))
+}
+
+func Partition[T, C any, S ~func(func(T) bool)](it S, pred func(T) bool, collector Collector[T, C]) (C, C) {
+	return partitionInto(iter.Seq[T](it), pred, collector, collector.New(), collector.New())
+}
+
+func partitionInto[T, C any](it iter.Seq[T], pred func(T) bool, collector Collector[T, C], satisfies, rest C) (C, C) {
+	for t := range it {
+		if pred(t) {
+			satisfies = collector.Extend(satisfies, Once(t))
+		} else {
+			rest = collector.Extend(rest, Once(t))
+		}
+	}
+	return satisfies, rest
+}
+
+func UnzipInto[T, O, C1, C2 any](it iter.Seq2[T, O], collector1 Collector[T, C1], collector2 Collector[O, C2]) (C1, C2) {
+	c1, c2 := collector1.New(), collector2.New()
+	for t, o := range it {
+		c1 = collector1.Extend(c1, Once(t))
+		c2 = collector2.Extend(c2, Once(o))
+	}
+	return c1, c2
+}
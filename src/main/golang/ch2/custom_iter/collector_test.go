@@ -0,0 +1,93 @@
+package custom_iter
+
+import (
+	"examples/ch2/custom_set"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestCollectSlice(t *testing.T) {
+	tcs := []struct {
+		input []int
+		want  []int
+	}{
+		{[]int{1, 2, 3}, []int{1, 2, 3}},
+		{nil, nil},
+	}
+	for _, tc := range tcs {
+		t.Run(fmt.Sprintf("collect slice %v", tc.input), func(t *testing.T) {
+			got := Collect(slices.Values(tc.input), SliceCollector[int]{})
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v want %v\n", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCollect2Map(t *testing.T) {
+	input := []string{"a", "b", "c"}
+	want := map[int]string{0: "a", 1: "b", 2: "c"}
+	got := Collect2(slices.All(input), MapCollector[int, string]{})
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v want %v\n", got, want)
+	}
+}
+
+func TestCollectSet(t *testing.T) {
+	got := Collect(slices.Values([]int{3, 1, 3, 2, 1}), SetCollector[int]{})
+	want := custom_set.Collect(slices.Values([]int{1, 2, 3}))
+	if !got.IsEqualTo(want) {
+		t.Errorf("got %v want %v\n", *got, *want)
+	}
+}
+
+func TestCollectBuilder(t *testing.T) {
+	got := Collect(slices.Values([]string{"ab", "", "cd"}), BuilderCollector{}).String()
+	if got != "abcd" {
+		t.Errorf("got %v want %v\n", got, "abcd")
+	}
+}
+
+func TestCollectChan(t *testing.T) {
+	ch := make(chan int, 3)
+	Collect(slices.Values([]int{1, 2, 3}), ChanCollector[int]{Ch: ch})
+	close(ch)
+	got := slices.Collect(maps.Keys(Collect(func(yield func(int) bool) {
+		for c := range ch {
+			if !yield(c) {
+				return
+			}
+		}
+	}, CountCollector[int]{})))
+	slices.Sort(got)
+	if !reflect.DeepEqual(got, []int{1, 2, 3}) {
+		t.Errorf("got %v want %v\n", got, []int{1, 2, 3})
+	}
+}
+
+func TestCollectCount(t *testing.T) {
+	got := Collect(slices.Values([]string{"a", "b", "a", "a"}), CountCollector[string]{})
+	want := map[string]uint{"a": 3, "b": 1}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v want %v\n", got, want)
+	}
+}
+
+func TestPartition(t *testing.T) {
+	got1, got2 := Partition(slices.Values([]int{1, 2, 3, 4, 5}), func(i int) bool { return i%2 == 0 }, SetCollector[int]{})
+	want1 := custom_set.Collect(slices.Values([]int{2, 4}))
+	want2 := custom_set.Collect(slices.Values([]int{1, 3, 5}))
+	if !got1.IsEqualTo(want1) || !got2.IsEqualTo(want2) {
+		t.Errorf("got %v %v want %v %v\n", *got1, *got2, *want1, *want2)
+	}
+}
+
+func TestUnzipInto(t *testing.T) {
+	got1, got2 := UnzipInto(slices.All([]string{"a", "b", "c"}), SliceCollector[int]{}, BuilderCollector{})
+	if !reflect.DeepEqual(got1, []int{0, 1, 2}) || got2.String() != "abc" {
+		t.Errorf("got %v %v want %v %v\n", got1, got2.String(), []int{0, 1, 2}, "abc")
+	}
+}
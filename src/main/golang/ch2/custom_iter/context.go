@@ -0,0 +1,105 @@
+package custom_iter
+
+import (
+	"cmp"
+	"context"
+	"iter"
+)
+
+// WithContext stops the sequence as soon as ctx is done.
+// The context is checked before ranging over it and again as each element arrives, before it is yielded,
+// so a cancelled Cycle or FlatMap chain ends at its next element.
+func WithContext[T any](ctx context.Context, it iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		_ = rangeCtx(ctx, it, yield)
+	}
+}
+
+func WithContext2[K, V any](ctx context.Context, it iter.Seq2[K, V]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if ctx.Err() != nil {
+			return
+		}
+		for k, v := range it {
+			if ctx.Err() != nil {
+				return
+			}
+			if !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+
+// rangeCtx ranges over it until ctx is done or yield returns false.
+// It returns ctx.Err() only when the context cut the sequence short.
+func rangeCtx[T any](ctx context.Context, it iter.Seq[T], yield func(T) bool) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	for t := range it {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if !yield(t) {
+			return nil
+		}
+	}
+	return nil
+}
+
+// ZipCtx releases the iter.Pull handle of other as soon as ctx is done.
+func ZipCtx[T, O any](ctx context.Context, it iter.Seq[T], other iter.Seq[O]) iter.Seq2[T, O] {
+	return Zip(WithContext(ctx, it), WithContext(ctx, other))
+}
+
+func ForEachCtx[T any](ctx context.Context, it iter.Seq[T], doFn func(T)) error {
+	return rangeCtx(ctx, it, func(t T) bool {
+		doFn(t)
+		return true
+	})
+}
+
+func FoldCtx[T, R any](ctx context.Context, it iter.Seq[T], init R, foldFn func(R, T) R) (R, error) {
+	acc := init
+	err := rangeCtx(ctx, it, func(t T) bool {
+		acc = foldFn(acc, t)
+		return true
+	})
+	return acc, err
+}
+
+func FindCtx[T any](ctx context.Context, it iter.Seq[T], pred func(T) bool) (T, bool, error) {
+	t, ok := Find(WithContext(ctx, it), pred)
+	if ok {
+		return t, true, nil
+	}
+	return t, false, ctx.Err()
+}
+
+func CountCtx[T any](ctx context.Context, it iter.Seq[T]) (uint, error) {
+	count := uint(0)
+	err := rangeCtx(ctx, it, func(T) bool {
+		count++
+		return true
+	})
+	return count, err
+}
+
+// EqCtx releases the iter.Pull handle of other as soon as ctx is done.
+func EqCtx[T comparable](ctx context.Context, it, other iter.Seq[T]) (bool, error) {
+	eq := Eq(WithContext(ctx, it), WithContext(ctx, other))
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+	return eq, nil
+}
+
+// CmpCtx releases the iter.Pull handle of other as soon as ctx is done.
+func CmpCtx[T cmp.Ordered](ctx context.Context, it, other iter.Seq[T]) (int, error) {
+	comp := Cmp(WithContext(ctx, it), WithContext(ctx, other))
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	return comp, nil
+}
@@ -0,0 +1,179 @@
+package custom_iter
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"slices"
+	"testing"
+)
+
+func TestWithContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var got []int
+	for i := range WithContext(ctx, Cycle(slices.Values([]int{1, 2, 3}))) {
+		got = append(got, i)
+		if len(got) == 4 {
+			cancel()
+		}
+	}
+	if !slices.Equal(got, []int{1, 2, 3, 1}) {
+		t.Errorf("got %v want %v\n", got, []int{1, 2, 3, 1})
+	}
+}
+
+func TestForEachCtx(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sum := 0
+	err := ForEachCtx(ctx, slices.Values([]int{1, 2, 3}), func(i int) { sum += i })
+	if sum != 6 || err != nil {
+		t.Errorf("got %v %v want %v %v\n", sum, err, 6, nil)
+	}
+	sum = 0
+	err = ForEachCtx(ctx, Cycle(slices.Values([]int{1, 2, 3})), func(i int) {
+		sum += i
+		if sum >= 10 {
+			cancel()
+		}
+	})
+	if sum != 12 || !errors.Is(err, context.Canceled) {
+		t.Errorf("got %v %v want %v %v\n", sum, err, 12, context.Canceled)
+	}
+}
+
+func TestFoldCtx(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	got, err := FoldCtx(ctx, slices.Values([]int{1, 2, 3}), 0, func(acc, i int) int { return acc + i })
+	if got != 6 || err != nil {
+		t.Errorf("got %v %v want %v %v\n", got, err, 6, nil)
+	}
+	cancel()
+	_, err = FoldCtx(ctx, Cycle(slices.Values([]int{1})), 0, func(acc, i int) int { return acc + i })
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("got %v want %v\n", err, context.Canceled)
+	}
+}
+
+func TestFindCtx(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	got, ok, err := FindCtx(ctx, Cycle(slices.Values([]int{1, 2, 3})), func(i int) bool { return i == 3 })
+	if got != 3 || !ok || err != nil {
+		t.Errorf("got %v %v %v want %v %v %v\n", got, ok, err, 3, true, nil)
+	}
+	_, ok, err = FindCtx(ctx, Cycle(slices.Values([]int{1, 2, 3})), func(i int) bool {
+		if i == 2 {
+			cancel()
+		}
+		return i == 4
+	})
+	if ok || !errors.Is(err, context.Canceled) {
+		t.Errorf("got %v %v want %v %v\n", ok, err, false, context.Canceled)
+	}
+}
+
+func TestCountCtx(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	got, err := CountCtx(ctx, slices.Values([]int{1, 2, 3}))
+	if got != 3 || err != nil {
+		t.Errorf("got %v %v want %v %v\n", got, err, 3, nil)
+	}
+	count := 0
+	got, err = CountCtx(ctx, Inspect(Cycle(slices.Values([]int{1})), func(int) {
+		count++
+		if count == 5 {
+			cancel()
+		}
+	}))
+	if got != 4 || !errors.Is(err, context.Canceled) {
+		t.Errorf("got %v %v want %v %v\n", got, err, 4, context.Canceled)
+	}
+}
+
+func TestCmpCtxReleasesPull(t *testing.T) {
+	released := false
+	other := func(yield func(int) bool) {
+		defer func() { released = true }()
+		for {
+			if !yield(1) {
+				return
+			}
+		}
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	count := 0
+	var it iter.Seq[int] = Inspect(Cycle(slices.Values([]int{1})), func(int) {
+		count++
+		if count == 3 {
+			cancel()
+		}
+	})
+	_, err := CmpCtx(ctx, it, other)
+	if !released || !errors.Is(err, context.Canceled) {
+		t.Errorf("got %v %v want %v %v\n", released, err, true, context.Canceled)
+	}
+	ok, err := EqCtx(ctx, slices.Values([]int{1}), slices.Values([]int{1}))
+	if ok || !errors.Is(err, context.Canceled) {
+		t.Errorf("got %v %v want %v %v\n", ok, err, false, context.Canceled)
+	}
+}
+
+func TestCtxFinishedBeforeCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	err := ForEachCtx(ctx, slices.Values([]int{1, 2, 3}), func(i int) {
+		if i == 3 {
+			cancel()
+		}
+	})
+	if err != nil {
+		t.Errorf("got %v want %v\n", err, nil)
+	}
+	ctx, cancel = context.WithCancel(context.Background())
+	sum, err := FoldCtx(ctx, slices.Values([]int{1, 2, 3}), 0, func(acc, i int) int {
+		if i == 3 {
+			cancel()
+		}
+		return acc + i
+	})
+	if sum != 6 || err != nil {
+		t.Errorf("got %v %v want %v %v\n", sum, err, 6, nil)
+	}
+	ctx, cancel = context.WithCancel(context.Background())
+	count, err := CountCtx(ctx, Inspect(slices.Values([]int{1, 2, 3}), func(i int) {
+		if i == 3 {
+			cancel()
+		}
+	}))
+	if count != 2 || !errors.Is(err, context.Canceled) {
+		t.Errorf("got %v %v want %v %v\n", count, err, 2, context.Canceled)
+	}
+}
+
+func TestZipCtxReleasesPull(t *testing.T) {
+	released := false
+	other := func(yield func(int) bool) {
+		defer func() { released = true }()
+		for i := 0; ; i++ {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var got []int
+	for a, b := range ZipCtx(ctx, Cycle(slices.Values([]int{1})), other) {
+		got = append(got, a+b)
+		if len(got) == 3 {
+			cancel()
+		}
+	}
+	if !released || !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("got %v %v want %v %v\n", released, got, true, []int{1, 2, 3})
+	}
+}
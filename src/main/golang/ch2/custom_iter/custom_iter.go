@@ -1,14 +1,14 @@
 // Package custom_iter is my Golang implementation of Iterator in Rust
 // nightly-only experimental API (as of Rust 1.86.0) were excluded from this implementation.
-// Methods that turn iterator into collection(s) such as collect & partition were limited to slice rather than generic "collection".
-// In my opinion in Golang, these methods need to be implemented on per collection type basis.
 // In Rust there is FromIterator trait which defines how conversion from Iterator to each Collection works.
-// However, in Golang such unified interface defining iterator -> collection conversion doesn't exist.
+// In Golang such unified interface doesn't exist, so Collector plays that role here.
+// CollectIntoSlice & PartitionIntoSlices are kept as shorthands of Collect & Partition with SliceCollector.
 package custom_iter
 
 import (
 	"cmp"
 	"iter"
+	"reflect"
 	"slices"
 )
 
@@ -44,7 +44,7 @@ func Nth[T any](it iter.Seq[T], n uint) (T, bool) {
 	return elem, ok
 }
 
-func StepBy[T any](it iter.Seq[T], step uint) iter.Seq[T] {
+func StepBy[T any, S ~func(func(T) bool)](it S, step uint) iter.Seq[T] {
 	if step == 0 {
 		panic("StepBy step cannot be zero")
 	}
@@ -61,7 +61,7 @@ func StepBy[T any](it iter.Seq[T], step uint) iter.Seq[T] {
 	}
 }
 
-func Chain[T any](it, other iter.Seq[T]) iter.Seq[T] {
+func Chain[T any, S1, S2 ~func(func(T) bool)](it S1, other S2) iter.Seq[T] {
 	return func(yield func(t T) bool) {
 		for t := range it {
 			if !yield(t) {
@@ -76,9 +76,9 @@ func Chain[T any](it, other iter.Seq[T]) iter.Seq[T] {
 	}
 }
 
-func Zip[T, O any](it iter.Seq[T], other iter.Seq[O]) iter.Seq2[T, O] {
+func Zip[T, O any, S1 ~func(func(T) bool), S2 ~func(func(O) bool)](it S1, other S2) iter.Seq2[T, O] {
 	return func(yield func(t T, o O) bool) {
-		next, stop := iter.Pull(other)
+		next, stop := iter.Pull(iter.Seq[O](other))
 		defer stop()
 		for t := range it {
 			o, ok := next()
@@ -126,43 +126,51 @@ func ByRef[T any](it iter.Seq[T]) iter.Seq[*T] {
 	}
 }
 
+// CollectIntoSlice grows the slice as it goes, since a plain iter.Seq carries no size hint.
+// Use CollectSized with SliceCollector to preallocate from a Sized source.
 func CollectIntoSlice[T any](it iter.Seq[T]) []T {
-	return slices.Collect[T](it)
+	return Collect(it, SliceCollector[T]{})
 }
 
+// PartitionIntoSlices grows both slices as it goes. Use PartitionSized to preallocate from a Sized source.
 func PartitionIntoSlices[T any](it iter.Seq[T], pred func(T) bool) ([]T, []T) {
-	var satisfies, rest []T
-	for t := range it {
-		if pred(t) {
-			satisfies = append(satisfies, t)
-		} else {
-			rest = append(rest, t)
-		}
-	}
-	return satisfies, rest
+	return Partition(it, pred, SliceCollector[T]{})
 }
 
 func TryFold[T, R any, E error](it iter.Seq[T], init R, foldFn func(R, T) (R, E)) (R, E) {
 	acc := init
-	var err error
+	var err E
 	for t := range it {
 		acc, err = foldFn(acc, t)
-		if err != nil {
-			return acc, err.(E)
+		if !isZero(err) {
+			return acc, err
 		}
 	}
-	return acc, err.(E)
+	return acc, err
+}
+
+// isZero reports whether err is the zero E, which TryFold and TryForEach take as success.
+// Comparing error(err) to nil is not enough: a nil *MyErr converted to error is not nil.
+// Interface and pointer errors, the common cases, are checked without allocating.
+func isZero[E any](err E) bool {
+	if any(err) == nil {
+		return true
+	}
+	if reflect.TypeFor[E]().Kind() == reflect.Interface {
+		return false
+	}
+	return reflect.ValueOf(err).IsZero()
 }
 
 func TryForEach[T any, E error](it iter.Seq[T], doFn func(T) E) E {
-	var err error
+	var err E
 	for t := range it {
 		err = doFn(t)
-		if err != nil {
-			return err.(E)
+		if !isZero(err) {
+			return err
 		}
 	}
-	return err.(E)
+	return err
 }
 
 func Fold[T, R any](it iter.Seq[T], init R, foldFn func(R, T) R) R {
@@ -233,7 +241,7 @@ func Filter[T any](it iter.Seq[T], pred func(T) bool) iter.Seq[T] {
 	}
 }
 
-func Map[T, R any](it iter.Seq[T], mapFn func(T) R) iter.Seq[R] {
+func Map[T, R any, S ~func(func(T) bool)](it S, mapFn func(T) R) iter.Seq[R] {
 	return func(yield func(R) bool) {
 		var r R
 		for t := range it {
@@ -260,7 +268,7 @@ func FilterMap[T, R any](it iter.Seq[T], filterMapFn func(T) (R, bool)) iter.Seq
 	}
 }
 
-func Enumerate[T any](it iter.Seq[T]) iter.Seq2[uint, T] {
+func Enumerate[T any, S ~func(func(T) bool)](it S) iter.Seq2[uint, T] {
 	return func(yield func(uint, T) bool) {
 		var index uint
 		for t := range it {
@@ -272,43 +280,9 @@ func Enumerate[T any](it iter.Seq[T]) iter.Seq2[uint, T] {
 	}
 }
 
-type PeekableSeq[T any] iter.Seq2[Option[T], Option[T]]
-
 type Option[T any] struct {
-	ok    bool
-	value T
-}
-
-func (peekableSeq PeekableSeq[T]) Peek() (T, bool) {
-	var value T
-	var ok bool
-	peekableSeq(func(first, second Option[T]) bool {
-		value, ok = second.value, second.ok
-		return false
-	})
-	return value, ok
-}
-
-func Peekable[T any](it iter.Seq[T]) PeekableSeq[T] {
-	return func(yield func(Option[T], Option[T]) bool) {
-		var prev T
-		var notFirst bool
-		for t := range it {
-			if !notFirst {
-				notFirst = true
-				prev = t
-				continue
-			}
-			if !yield(Option[T]{ok: true, value: prev}, Option[T]{ok: true, value: t}) {
-				prev = t
-				return
-			}
-			prev = t
-		}
-		if !yield(Option[T]{ok: true, value: prev}, Option[T]{ok: false}) {
-			return
-		}
-	}
+	Ok    bool
+	Value T
 }
 
 func SkipWhile[T any](it iter.Seq[T], pred func(T) bool) iter.Seq[T] {
@@ -379,17 +353,23 @@ func Skip[T any](it iter.Seq[T], n uint) iter.Seq[T] {
 	}
 }
 
-func Take[T any](it iter.Seq[T], n uint) iter.Seq[T] {
+// Take yields the first n elements of it. It stops ranging it right after
+// the n-th element, so a source with side effects never produces more than
+// n elements, and it doesn't range it at all when n is 0.
+func Take[T any, S ~func(func(T) bool)](it S, n uint) iter.Seq[T] {
 	return func(yield func(T) bool) {
+		if n == 0 {
+			return
+		}
 		index := uint(0)
 		for t := range it {
-			if index >= n {
-				break
-			}
 			if !yield(t) {
 				return
 			}
 			index++
+			if index >= n {
+				return
+			}
 		}
 	}
 }
@@ -441,7 +421,7 @@ func Flatten[T any](it iter.Seq[iter.Seq[T]]) iter.Seq[T] {
 func Fuse[T any](it iter.Seq[Option[T]]) iter.Seq[Option[T]] {
 	return func(yield func(Option[T]) bool) {
 		for t := range it {
-			if !t.ok {
+			if !t.Ok {
 				break
 			}
 			if !yield(t) {
@@ -627,6 +607,7 @@ func Rev[T any](it iter.Seq[T]) iter.Seq[T] {
 	}
 }
 
+// Unzip ranges over it once per returned sequence. Use UnzipSinglePass for single-shot sources.
 func Unzip[T, O any](it iter.Seq2[T, O]) (iter.Seq[T], iter.Seq[O]) {
 	var it1 iter.Seq[T]
 	var it2 iter.Seq[O]
@@ -1,6 +1,7 @@
 package custom_iter
 
 import (
+	"errors"
 	"fmt"
 	"iter"
 	"reflect"
@@ -276,4 +277,119 @@ func TestPartitionIntoSlices(t *testing.T) {
 	}
 }
 
-// TODO: Add tests for TryFold and the rest
+func TestTryFold(t *testing.T) {
+	errNegative := errors.New("negative")
+	tcs := []struct {
+		input   []int
+		want    int
+		wantErr error
+	}{
+		{[]int{1, 2, 3}, 6, nil},
+		{nil, 0, nil},
+		{[]int{1, -2, 3}, 1, errNegative},
+	}
+	for _, tc := range tcs {
+		t.Run(fmt.Sprintf("try fold %v", tc.input), func(t *testing.T) {
+			got, err := TryFold(slices.Values(tc.input), 0, func(acc, i int) (int, error) {
+				if i < 0 {
+					return acc, errNegative
+				}
+				return acc + i, nil
+			})
+			if got != tc.want || err != tc.wantErr {
+				t.Errorf("got %v %v want %v %v\n", got, err, tc.want, tc.wantErr)
+			}
+		})
+	}
+}
+
+type negativeError struct {
+	value int
+}
+
+func (e *negativeError) Error() string {
+	return fmt.Sprintf("negative %d", e.value)
+}
+
+func TestTryPointerError(t *testing.T) {
+	sum := func(acc, i int) (int, *negativeError) {
+		if i < 0 {
+			return acc, &negativeError{i}
+		}
+		return acc + i, nil
+	}
+	if got, err := TryFold(slices.Values([]int{1, 2, 3}), 0, sum); got != 6 || err != nil {
+		t.Errorf("got %v %v want %v %v\n", got, err, 6, nil)
+	}
+	if got, err := TryFold(slices.Values([]int{1, -2, 3}), 0, sum); got != 1 || err == nil || err.value != -2 {
+		t.Errorf("got %v %v want %v %v\n", got, err, 1, &negativeError{-2})
+	}
+	count := 0
+	err := TryForEach(slices.Values([]int{1, 2, 3}), func(int) *negativeError {
+		count++
+		return nil
+	})
+	if count != 3 || err != nil {
+		t.Errorf("got %v %v want %v %v\n", count, err, 3, nil)
+	}
+}
+
+func TestIsZeroAllocs(t *testing.T) {
+	var pointerErr *negativeError
+	var interfaceErr error = errors.ErrUnsupported
+	allocs := testing.AllocsPerRun(100, func() {
+		if !isZero(pointerErr) || isZero(interfaceErr) || !isZero[error](nil) {
+			t.Errorf("wrong zero check\n")
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("got %v allocs want %v\n", allocs, 0)
+	}
+}
+
+func TestTryForEach(t *testing.T) {
+	errNegative := errors.New("negative")
+	tcs := []struct {
+		input   []int
+		want    []int
+		wantErr error
+	}{
+		{[]int{1, 2, 3}, []int{1, 2, 3}, nil},
+		{[]int{1, -2, 3}, []int{1}, errNegative},
+	}
+	for _, tc := range tcs {
+		t.Run(fmt.Sprintf("try for each %v", tc.input), func(t *testing.T) {
+			var got []int
+			err := TryForEach(slices.Values(tc.input), func(i int) error {
+				if i < 0 {
+					return errNegative
+				}
+				got = append(got, i)
+				return nil
+			})
+			if !reflect.DeepEqual(got, tc.want) || err != tc.wantErr {
+				t.Errorf("got %v %v want %v %v\n", got, err, tc.want, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestTakeSideEffects(t *testing.T) {
+	for _, n := range []uint{0, 1, 3} {
+		t.Run(strconv.Itoa(int(n)), func(t *testing.T) {
+			calls := uint(0)
+			got := slices.Collect(Take(RepeatWith(func() uint { calls++; return calls }), n))
+			if calls != n || uint(len(got)) != n {
+				t.Errorf("got %v calls %v want %v calls\n", got, calls, n)
+			}
+		})
+	}
+	ranged := false
+	for range Take(func(yield func(int) bool) { ranged = true }, 0) {
+	}
+	if ranged {
+		t.Errorf("got ranged source want untouched source for n == 0\n")
+	}
+}
+
+// TODO: Add tests for the rest
@@ -0,0 +1,91 @@
+package custom_iter
+
+import (
+	"examples/ch2/custom_set"
+	"iter"
+)
+
+// Dedup collapses runs of consecutive equal elements into one.
+func Dedup[T comparable](it iter.Seq[T]) iter.Seq[T] {
+	return DedupBy(it, func(a, b T) bool {
+		return a == b
+	})
+}
+
+func DedupBy[T any](it iter.Seq[T], sameFn func(T, T) bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		var prev T
+		notFirst := false
+		for t := range it {
+			if notFirst && sameFn(prev, t) {
+				continue
+			}
+			prev, notFirst = t, true
+			if !yield(t) {
+				return
+			}
+		}
+	}
+}
+
+func DedupByKey[T any, K comparable](it iter.Seq[T], keyFn func(T) K) iter.Seq[T] {
+	return DedupBy(it, func(a, b T) bool {
+		return keyFn(a) == keyFn(b)
+	})
+}
+
+// Unique yields only the first occurrence of every element.
+func Unique[T comparable](it iter.Seq[T]) iter.Seq[T] {
+	return UniqueBy(it, func(t T) T {
+		return t
+	})
+}
+
+func UniqueBy[T any, K comparable](it iter.Seq[T], keyFn func(T) K) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		seen := custom_set.New[K]()
+		for t := range it {
+			if _, added := seen.Add(keyFn(t)); !added {
+				continue
+			}
+			if !yield(t) {
+				return
+			}
+		}
+	}
+}
+
+// Duplicates yields an element once, when it is seen for the second time.
+func Duplicates[T comparable](it iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		seen, reported := custom_set.New[T](), custom_set.New[T]()
+		for t := range it {
+			if _, added := seen.Add(t); added {
+				continue
+			}
+			if _, added := reported.Add(t); !added {
+				continue
+			}
+			if !yield(t) {
+				return
+			}
+		}
+	}
+}
+
+func Counts[T comparable](it iter.Seq[T]) map[T]uint {
+	return Collect(it, CountCollector[T]{})
+}
+
+func CountsBy[T any, K comparable](it iter.Seq[T], keyFn func(T) K) map[K]uint {
+	return Counts(Map(it, keyFn))
+}
+
+func GroupByKey[T any, K comparable](it iter.Seq[T], keyFn func(T) K) map[K][]T {
+	groups := make(map[K][]T)
+	for t := range it {
+		key := keyFn(t)
+		groups[key] = append(groups[key], t)
+	}
+	return groups
+}
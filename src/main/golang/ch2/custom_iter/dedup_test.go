@@ -0,0 +1,68 @@
+package custom_iter
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestDedup(t *testing.T) {
+	tcs := []struct {
+		input []int
+		want  []int
+	}{
+		{[]int{1, 1, 2, 2, 2, 1, 3, 3}, []int{1, 2, 1, 3}},
+		{[]int{1}, []int{1}},
+		{nil, nil},
+	}
+	for _, tc := range tcs {
+		t.Run(fmt.Sprintf("dedup %v", tc.input), func(t *testing.T) {
+			got := slices.Collect(Dedup(slices.Values(tc.input)))
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v want %v\n", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDedupByKey(t *testing.T) {
+	got := slices.Collect(DedupByKey(slices.Values([]string{"a", "A", "b", "B", "a"}), strings.ToLower))
+	want := []string{"a", "b", "a"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v want %v\n", got, want)
+	}
+}
+
+func TestUnique(t *testing.T) {
+	got := slices.Collect(Unique(slices.Values([]int{3, 1, 3, 2, 1, 4})))
+	if want := []int{3, 1, 2, 4}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v want %v\n", got, want)
+	}
+	got2 := slices.Collect(UniqueBy(slices.Values([]string{"a", "A", "b", "B"}), strings.ToLower))
+	if want := []string{"a", "b"}; !reflect.DeepEqual(got2, want) {
+		t.Errorf("got %v want %v\n", got2, want)
+	}
+}
+
+func TestDuplicates(t *testing.T) {
+	got := slices.Collect(Duplicates(slices.Values([]int{1, 2, 1, 3, 1, 2})))
+	if want := []int{1, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v want %v\n", got, want)
+	}
+}
+
+func TestCounts(t *testing.T) {
+	got := CountsBy(slices.Values([]string{"a", "A", "b"}), strings.ToLower)
+	if want := map[string]uint{"a": 2, "b": 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v want %v\n", got, want)
+	}
+}
+
+func TestGroupByKey(t *testing.T) {
+	got := GroupByKey(slices.Values([]int{1, 2, 3, 4, 5}), func(i int) bool { return i%2 == 0 })
+	if want := map[bool][]int{true: {2, 4}, false: {1, 3, 5}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v want %v\n", got, want)
+	}
+}
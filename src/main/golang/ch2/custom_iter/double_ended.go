@@ -0,0 +1,229 @@
+package custom_iter
+
+import (
+	"iter"
+	"math"
+)
+
+// RandomAccess is implemented by sequences whose elements can be read by index, like DoubleEndedSeq.
+type RandomAccess[T any] interface {
+	All() iter.Seq[T]
+	Len() uint
+	At(i uint) T
+}
+
+// DoubleEndedSeq is a random-access view over a source such as a slice, a string or a numeric range.
+// Its Len, Last, Nth, Skip, Take and Rev run in O(1) without walking the elements. Range over All.
+// The free functions Count, Last, Nth, Skip and Rev take a plain iter.Seq, which hides the index, so they always walk it.
+type DoubleEndedSeq[T any] struct {
+	len uint
+	at  func(uint) T
+}
+
+func newDoubleEndedSeq[T any](n uint, at func(uint) T) DoubleEndedSeq[T] {
+	return DoubleEndedSeq[T]{len: n, at: at}
+}
+
+func FromSlice[T any](s []T) DoubleEndedSeq[T] {
+	return newDoubleEndedSeq(uint(len(s)), func(i uint) T {
+		return s[i]
+	})
+}
+
+// FromString yields the bytes of s. Runes are not random-access.
+func FromString(s string) DoubleEndedSeq[byte] {
+	return newDoubleEndedSeq(uint(len(s)), func(i uint) byte {
+		return s[i]
+	})
+}
+
+func (s DoubleEndedSeq[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := range s.len {
+			if !yield(s.at(i)) {
+				return
+			}
+		}
+	}
+}
+
+func (s DoubleEndedSeq[T]) Len() uint {
+	return s.len
+}
+
+// At panics when i is out of range.
+func (s DoubleEndedSeq[T]) At(i uint) T {
+	if i >= s.len {
+		panic("DoubleEndedSeq index out of range")
+	}
+	return s.at(i)
+}
+
+func (s DoubleEndedSeq[T]) SizeHint() SizeHint {
+	return ExactSize(s.len)
+}
+
+func (s DoubleEndedSeq[T]) Nth(n uint) (T, bool) {
+	if n >= s.len {
+		var zero T
+		return zero, false
+	}
+	return s.at(n), true
+}
+
+func (s DoubleEndedSeq[T]) Last() (T, bool) {
+	if s.len == 0 {
+		var zero T
+		return zero, false
+	}
+	return s.at(s.len - 1), true
+}
+
+func (s DoubleEndedSeq[T]) Skip(n uint) DoubleEndedSeq[T] {
+	n = min(n, s.len)
+	return newDoubleEndedSeq(s.len-n, func(i uint) T {
+		return s.at(i + n)
+	})
+}
+
+func (s DoubleEndedSeq[T]) Take(n uint) DoubleEndedSeq[T] {
+	return newDoubleEndedSeq(min(n, s.len), s.at)
+}
+
+func (s DoubleEndedSeq[T]) Rev() DoubleEndedSeq[T] {
+	return newDoubleEndedSeq(s.len, func(i uint) T {
+		return s.at(s.len - 1 - i)
+	})
+}
+
+// Iter returns a cursor that can be advanced from both ends.
+func (s DoubleEndedSeq[T]) Iter() *DoubleEndedIter[T] {
+	return &DoubleEndedIter[T]{at: s.at, front: 0, back: s.len}
+}
+
+// DoubleEndedIter holds the remaining elements in [front, back).
+type DoubleEndedIter[T any] struct {
+	at          func(uint) T
+	front, back uint
+}
+
+func (it *DoubleEndedIter[T]) Len() uint {
+	return it.back - it.front
+}
+
+func (it *DoubleEndedIter[T]) Next() (T, bool) {
+	return it.Nth(0)
+}
+
+func (it *DoubleEndedIter[T]) NextBack() (T, bool) {
+	return it.NthBack(0)
+}
+
+func (it *DoubleEndedIter[T]) Nth(n uint) (T, bool) {
+	if n >= it.Len() {
+		it.front = it.back
+		var zero T
+		return zero, false
+	}
+	it.front += n + 1
+	return it.at(it.front - 1), true
+}
+
+func (it *DoubleEndedIter[T]) NthBack(n uint) (T, bool) {
+	if n >= it.Len() {
+		it.back = it.front
+		var zero T
+		return zero, false
+	}
+	it.back -= n + 1
+	return it.at(it.back), true
+}
+
+func (it *DoubleEndedIter[T]) RFind(pred func(T) bool) (T, bool) {
+	for {
+		t, ok := it.NextBack()
+		if !ok || pred(t) {
+			return t, ok
+		}
+	}
+}
+
+func RFold[T, R any](it *DoubleEndedIter[T], init R, foldFn func(R, T) R) R {
+	acc := init
+	for {
+		t, ok := it.NextBack()
+		if !ok {
+			return acc
+		}
+		acc = foldFn(acc, t)
+	}
+}
+
+// Range yields start, start+step, ... up to but excluding end.
+// Every element is computed as start+i*step, so float steps do not accumulate error.
+func Range[T numeric](start, end, step T) DoubleEndedSeq[T] {
+	return numericRange(start, end, step, false, false)
+}
+
+func RangeInclusive[T numeric](start, end, step T) DoubleEndedSeq[T] {
+	return numericRange(start, end, step, true, false)
+}
+
+// RangeDown yields start, start-step, ... down to but excluding end. The step is still positive.
+func RangeDown[T numeric](start, end, step T) DoubleEndedSeq[T] {
+	return numericRange(start, end, step, false, true)
+}
+
+func RangeDownInclusive[T numeric](start, end, step T) DoubleEndedSeq[T] {
+	return numericRange(start, end, step, true, true)
+}
+
+func numericRange[T numeric](start, end, step T, inclusive, descending bool) DoubleEndedSeq[T] {
+	if step <= 0 {
+		panic("Range step must be positive")
+	}
+	lo, hi := start, end
+	if descending {
+		lo, hi = end, start
+	}
+	var n uint
+	if lo < hi || (inclusive && lo == hi) {
+		n = rangeLen(lo, hi, step, inclusive)
+	}
+	if descending {
+		return newDoubleEndedSeq(n, func(i uint) T {
+			return start - T(i)*step
+		})
+	}
+	return newDoubleEndedSeq(n, func(i uint) T {
+		return start + T(i)*step
+	})
+}
+
+// rangeLen counts the elements in [lo, hi) or [lo, hi]. Integers are counted in uint64 so that
+// the difference cannot overflow narrow types like int8.
+func rangeLen[T numeric](lo, hi, step T, inclusive bool) uint {
+	if isFloat[T]() {
+		count := T(math.Floor(float64(hi-lo) / float64(step)))
+		if inclusive {
+			if lo+count*step <= hi {
+				count++
+			}
+		} else if lo+count*step < hi {
+			count++
+		}
+		return uint(count)
+	}
+	diff, ustep := uint64(hi)-uint64(lo), uint64(step)
+	count := diff / ustep
+	if inclusive || diff%ustep != 0 {
+		count++
+	}
+	return uint(count)
+}
+
+func isFloat[T numeric]() bool {
+	var half T = 1
+	half /= 2
+	return half != 0
+}
@@ -0,0 +1,162 @@
+package custom_iter
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestDoubleEndedShortcuts(t *testing.T) {
+	calls := 0
+	input := []int{1, 2, 3, 4, 5}
+	s := newDoubleEndedSeq(uint(len(input)), func(i uint) int {
+		calls++
+		return input[i]
+	})
+	if got := s.Len(); got != 5 || calls != 0 {
+		t.Errorf("got %v %v want %v %v\n", got, calls, 5, 0)
+	}
+	if got, ok := s.Last(); got != 5 || !ok || calls != 1 {
+		t.Errorf("got %v %v %v want %v %v %v\n", got, ok, calls, 5, true, 1)
+	}
+	if got, ok := s.Nth(3); got != 4 || !ok || calls != 2 {
+		t.Errorf("got %v %v %v want %v %v %v\n", got, ok, calls, 4, true, 2)
+	}
+	if got := slices.Collect(s.Skip(3).All()); !reflect.DeepEqual(got, []int{4, 5}) || calls != 4 {
+		t.Errorf("got %v %v want %v %v\n", got, calls, []int{4, 5}, 4)
+	}
+	if got := slices.Collect(s.Rev().Take(3).All()); !reflect.DeepEqual(got, []int{5, 4, 3}) {
+		t.Errorf("got %v want %v\n", got, []int{5, 4, 3})
+	}
+	if got := s.Rev().Skip(1).At(0); got != 4 {
+		t.Errorf("got %v want %v\n", got, 4)
+	}
+	allocs := testing.AllocsPerRun(100, func() {
+		s.Len()
+		s.Last()
+		s.Nth(2)
+	})
+	if allocs != 0 {
+		t.Errorf("got %v allocations want %v\n", allocs, 0)
+	}
+}
+
+func TestDoubleEndedFallback(t *testing.T) {
+	if got := slices.Collect(Rev(slices.Values([]int{1, 2, 3}))); !reflect.DeepEqual(got, []int{3, 2, 1}) {
+		t.Errorf("got %v want %v\n", got, []int{3, 2, 1})
+	}
+	if got, ok := Nth(FromString("abc").All(), 5); got != 0 || ok {
+		t.Errorf("got %v %v want %v %v\n", got, ok, 0, false)
+	}
+	if got, ok := FromSlice([]int{}).Last(); got != 0 || ok {
+		t.Errorf("got %v %v want %v %v\n", got, ok, 0, false)
+	}
+	if got := Count(FromSlice([]int{1, 2}).All()); got != 2 {
+		t.Errorf("got %v want %v\n", got, 2)
+	}
+}
+
+func TestDoubleEndedIter(t *testing.T) {
+	it := FromString("abcdef").Iter()
+	if got, ok := it.Next(); got != 'a' || !ok {
+		t.Errorf("got %c %v want %c %v\n", got, ok, 'a', true)
+	}
+	if got, ok := it.NextBack(); got != 'f' || !ok {
+		t.Errorf("got %c %v want %c %v\n", got, ok, 'f', true)
+	}
+	if got, ok := it.NthBack(1); got != 'd' || !ok || it.Len() != 2 {
+		t.Errorf("got %c %v %v want %c %v %v\n", got, ok, it.Len(), 'd', true, 2)
+	}
+	if got, ok := it.RFind(func(b byte) bool { return b == 'b' }); got != 'b' || !ok || it.Len() != 0 {
+		t.Errorf("got %c %v %v want %c %v %v\n", got, ok, it.Len(), 'b', true, 0)
+	}
+	if got, ok := it.Next(); ok {
+		t.Errorf("got %c %v want %v\n", got, ok, false)
+	}
+	got := RFold(FromSlice([]string{"a", "b", "c"}).Iter(), "", func(acc, s string) string { return acc + s })
+	if got != "cba" {
+		t.Errorf("got %v want %v\n", got, "cba")
+	}
+}
+
+func TestRange(t *testing.T) {
+	tcs := []struct {
+		start, end, step float64
+		want             uint
+	}{
+		{0, 1, 0.1, 10},
+		{0, 10, 3, 4},
+		{0, 9, 3, 3},
+		{5, 1, 1, 0},
+	}
+	for _, tc := range tcs {
+		t.Run(fmt.Sprintf("range %v %v %v", tc.start, tc.end, tc.step), func(t *testing.T) {
+			if got := Range(tc.start, tc.end, tc.step).Len(); got != tc.want {
+				t.Errorf("got %v want %v\n", got, tc.want)
+			}
+		})
+	}
+	if got := slices.Collect(Range[uint8](1, 10, 4).Rev().All()); !reflect.DeepEqual(got, []uint8{9, 5, 1}) {
+		t.Errorf("got %v want %v\n", got, []uint8{9, 5, 1})
+	}
+}
+
+func TestRangeVariants(t *testing.T) {
+	tcs := []struct {
+		description string
+		got         []int8
+		want        []int8
+	}{
+		{"range", CollectIntoSlice(Range[int8](0, 10, 3).All()), []int8{0, 3, 6, 9}},
+		{"inclusive", CollectIntoSlice(RangeInclusive[int8](0, 9, 3).All()), []int8{0, 3, 6, 9}},
+		{"down", CollectIntoSlice(RangeDown[int8](10, 0, 5).All()), []int8{10, 5}},
+		{"down inclusive", CollectIntoSlice(RangeDownInclusive[int8](10, 0, 5).All()), []int8{10, 5, 0}},
+		{"single inclusive", CollectIntoSlice(RangeInclusive[int8](4, 4, 1).All()), []int8{4}},
+		{"wrong direction", CollectIntoSlice(RangeDown[int8](0, 10, 1).All()), nil},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.description, func(t *testing.T) {
+			if !reflect.DeepEqual(tc.got, tc.want) {
+				t.Errorf("got %v want %v\n", tc.got, tc.want)
+			}
+		})
+	}
+	if got := RangeInclusive[int8](-128, 127, 1).Len(); got != 256 {
+		t.Errorf("got %v want %v\n", got, 256)
+	}
+	if got, _ := RangeInclusive[int8](-128, 127, 1).Last(); got != 127 {
+		t.Errorf("got %v want %v\n", got, 127)
+	}
+	if got := RangeDown[uint](10, 0, 3).Len(); got != 4 {
+		t.Errorf("got %v want %v\n", got, 4)
+	}
+}
+
+func TestRangeFloat(t *testing.T) {
+	tcs := []struct {
+		start, end, step float64
+		inclusive        bool
+		want             uint
+	}{
+		{0, 1, 0.1, false, 10},
+		{0, 1, 0.1, true, 11},
+		{0, 1, 0.25, true, 5},
+	}
+	for _, tc := range tcs {
+		t.Run(fmt.Sprintf("range float %v %v %v %v", tc.start, tc.end, tc.step, tc.inclusive), func(t *testing.T) {
+			r := Range(tc.start, tc.end, tc.step)
+			if tc.inclusive {
+				r = RangeInclusive(tc.start, tc.end, tc.step)
+			}
+			if got := r.Len(); got != tc.want {
+				t.Errorf("got %v want %v\n", got, tc.want)
+			}
+		})
+	}
+	got, _ := Range(0, 1000, 0.1).Nth(9999)
+	if want := 999.9; math.Abs(got-want) > 1e-9 {
+		t.Errorf("got %v want %v\n", got, want)
+	}
+}
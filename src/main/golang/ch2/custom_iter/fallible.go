@@ -0,0 +1,126 @@
+package custom_iter
+
+import "iter"
+
+// Fallible lifts it into a fallible sequence, iter.Seq2[T, error].
+// Every Try* adaptor over fallible sequences stops after yielding the first error.
+func Fallible[T any](it iter.Seq[T]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for t := range it {
+			if !yield(t, nil) {
+				return
+			}
+		}
+	}
+}
+
+func TryMap[T, R any](it iter.Seq2[T, error], mapFn func(T) (R, error)) iter.Seq2[R, error] {
+	return func(yield func(R, error) bool) {
+		var zero R
+		for t, err := range it {
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			r, err := mapFn(t)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			if !yield(r, nil) {
+				return
+			}
+		}
+	}
+}
+
+func TryFilter[T any](it iter.Seq2[T, error], pred func(T) (bool, error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		for t, err := range it {
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			ok, err := pred(t)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			if ok {
+				if !yield(t, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func TryFlatMap[T, R any](it iter.Seq2[T, error], mapFn func(T) iter.Seq2[R, error]) iter.Seq2[R, error] {
+	return func(yield func(R, error) bool) {
+		var zero R
+		for t, err := range it {
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			for r, err := range mapFn(t) {
+				if err != nil {
+					yield(zero, err)
+					return
+				}
+				if !yield(r, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func TryCollect[T any](it iter.Seq2[T, error]) ([]T, error) {
+	var result []T
+	for t, err := range it {
+		if err != nil {
+			return result, err
+		}
+		result = append(result, t)
+	}
+	return result, nil
+}
+
+func TryFind[T any](it iter.Seq2[T, error], pred func(T) (bool, error)) (T, bool, error) {
+	var zero T
+	for t, err := range it {
+		if err != nil {
+			return zero, false, err
+		}
+		ok, err := pred(t)
+		if err != nil {
+			return zero, false, err
+		}
+		if ok {
+			return t, true, nil
+		}
+	}
+	return zero, false, nil
+}
+
+func TryReduce[T any](it iter.Seq2[T, error], reduceFn func(T, T) (T, error)) (T, bool, error) {
+	var acc T
+	ok := false
+	for t, err := range it {
+		if err != nil {
+			return acc, ok, err
+		}
+		if !ok {
+			acc = t
+			ok = true
+		} else {
+			acc, err = reduceFn(acc, t)
+			if err != nil {
+				return acc, ok, err
+			}
+		}
+	}
+	return acc, ok, nil
+}
@@ -0,0 +1,110 @@
+package custom_iter
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"reflect"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func parseAll(input []string) iter.Seq2[int, error] {
+	return TryMap(Fallible(slices.Values(input)), strconv.Atoi)
+}
+
+func TestTryMap(t *testing.T) {
+	tcs := []struct {
+		input   []string
+		want    []int
+		wantErr bool
+	}{
+		{[]string{"1", "2", "3"}, []int{1, 2, 3}, false},
+		{[]string{"1", "x", "3"}, []int{1}, true},
+		{nil, nil, false},
+	}
+	for _, tc := range tcs {
+		t.Run(fmt.Sprintf("try map %v", tc.input), func(t *testing.T) {
+			got, err := TryCollect(parseAll(tc.input))
+			if !reflect.DeepEqual(got, tc.want) || (err != nil) != tc.wantErr {
+				t.Errorf("got %v %v want %v %v\n", got, err, tc.want, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestTryFilter(t *testing.T) {
+	errTooBig := errors.New("too big")
+	pred := func(i int) (bool, error) {
+		if i > 5 {
+			return false, errTooBig
+		}
+		return i%2 == 1, nil
+	}
+	got, err := TryCollect(TryFilter(parseAll([]string{"1", "2", "3"}), pred))
+	if !reflect.DeepEqual(got, []int{1, 3}) || err != nil {
+		t.Errorf("got %v %v want %v %v\n", got, err, []int{1, 3}, nil)
+	}
+	got, err = TryCollect(TryFilter(parseAll([]string{"1", "9", "3"}), pred))
+	if !reflect.DeepEqual(got, []int{1}) || err != errTooBig {
+		t.Errorf("got %v %v want %v %v\n", got, err, []int{1}, errTooBig)
+	}
+}
+
+func TestTryFlatMap(t *testing.T) {
+	got, err := TryCollect(TryFlatMap(Fallible(slices.Values([]string{"1 2", "3"})), func(s string) iter.Seq2[int, error] {
+		return parseAll(slices.Collect(func(yield func(string) bool) {
+			for _, r := range s {
+				if r != ' ' && !yield(string(r)) {
+					return
+				}
+			}
+		}))
+	}))
+	if !reflect.DeepEqual(got, []int{1, 2, 3}) || err != nil {
+		t.Errorf("got %v %v want %v %v\n", got, err, []int{1, 2, 3}, nil)
+	}
+}
+
+func TestTryFind(t *testing.T) {
+	tcs := []struct {
+		input   []string
+		want    int
+		wantOk  bool
+		wantErr bool
+	}{
+		{[]string{"1", "4", "x"}, 4, true, false},
+		{[]string{"1", "x", "4"}, 0, false, true},
+		{[]string{"1", "3"}, 0, false, false},
+	}
+	for _, tc := range tcs {
+		t.Run(fmt.Sprintf("try find %v", tc.input), func(t *testing.T) {
+			got, ok, err := TryFind(parseAll(tc.input), func(i int) (bool, error) { return i%2 == 0, nil })
+			if got != tc.want || ok != tc.wantOk || (err != nil) != tc.wantErr {
+				t.Errorf("got %v %v %v want %v %v %v\n", got, ok, err, tc.want, tc.wantOk, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestTryReduce(t *testing.T) {
+	tcs := []struct {
+		input   []string
+		want    int
+		wantOk  bool
+		wantErr bool
+	}{
+		{[]string{"1", "2", "3"}, 6, true, false},
+		{nil, 0, false, false},
+		{[]string{"1", "x"}, 1, true, true},
+	}
+	for _, tc := range tcs {
+		t.Run(fmt.Sprintf("try reduce %v", tc.input), func(t *testing.T) {
+			got, ok, err := TryReduce(parseAll(tc.input), func(a, b int) (int, error) { return a + b, nil })
+			if got != tc.want || ok != tc.wantOk || (err != nil) != tc.wantErr {
+				t.Errorf("got %v %v %v want %v %v %v\n", got, ok, err, tc.want, tc.wantOk, tc.wantErr)
+			}
+		})
+	}
+}
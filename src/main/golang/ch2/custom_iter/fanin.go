@@ -0,0 +1,111 @@
+package custom_iter
+
+import (
+	"iter"
+	"sync"
+)
+
+type fanInItem[T any] struct {
+	value     T
+	panicked  bool
+	panicInfo any
+}
+
+// fanInProduce drives seq on the calling goroutine and forwards its elements, or its panic, to out until done is closed.
+func fanInProduce[T any](seq iter.Seq[T], out chan<- fanInItem[T], done <-chan struct{}) {
+	defer func() {
+		if p := recover(); p != nil {
+			select {
+			case out <- fanInItem[T]{panicked: true, panicInfo: p}:
+			case <-done:
+			}
+		}
+	}()
+	for t := range seq {
+		select {
+		case out <- fanInItem[T]{value: t}:
+		case <-done:
+			return
+		}
+	}
+}
+
+// FanIn drives every sequence on its own goroutine and yields the elements as they arrive.
+// bufSize is the capacity of the channel shared by the producers.
+// Breaking out of the loop shuts every producer down before the sequence returns,
+// and a panic in any producer is re-raised on the consumer.
+func FanIn[T any](bufSize uint, seqs ...iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		done := make(chan struct{})
+		out := make(chan fanInItem[T], bufSize)
+		var wg sync.WaitGroup
+		defer func() {
+			close(done)
+			wg.Wait()
+		}()
+		var producers sync.WaitGroup
+		for _, seq := range seqs {
+			wg.Add(1)
+			producers.Add(1)
+			go func() {
+				defer wg.Done()
+				defer producers.Done()
+				fanInProduce(seq, out, done)
+			}()
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			producers.Wait()
+			close(out)
+		}()
+		for item := range out {
+			if item.panicked {
+				panic(item.panicInfo)
+			}
+			if !yield(item.value) {
+				return
+			}
+		}
+	}
+}
+
+// FanInOrdered is FanIn that takes one element from each live sequence in turn, so the output order is deterministic.
+// Every producer has its own channel of bufSize elements.
+func FanInOrdered[T any](bufSize uint, seqs ...iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		done := make(chan struct{})
+		var wg sync.WaitGroup
+		defer func() {
+			close(done)
+			wg.Wait()
+		}()
+		chans := make([]chan fanInItem[T], len(seqs))
+		for i, seq := range seqs {
+			chans[i] = make(chan fanInItem[T], bufSize)
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer close(chans[i])
+				fanInProduce(seq, chans[i], done)
+			}()
+		}
+		for len(chans) > 0 {
+			live := chans[:0]
+			for _, ch := range chans {
+				item, ok := <-ch
+				if !ok {
+					continue
+				}
+				live = append(live, ch)
+				if item.panicked {
+					panic(item.panicInfo)
+				}
+				if !yield(item.value) {
+					return
+				}
+			}
+			chans = live
+		}
+	}
+}
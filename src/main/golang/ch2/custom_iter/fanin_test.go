@@ -0,0 +1,68 @@
+package custom_iter
+
+import (
+	"fmt"
+	"iter"
+	"reflect"
+	"runtime"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestFanIn(t *testing.T) {
+	for _, bufSize := range []uint{0, 4} {
+		t.Run(fmt.Sprintf("fan in %v", bufSize), func(t *testing.T) {
+			got := slices.Collect(FanIn(bufSize, slices.Values([]int{1, 2, 3}), slices.Values([]int{4, 5}), Empty[int]()))
+			slices.Sort(got)
+			if want := []int{1, 2, 3, 4, 5}; !reflect.DeepEqual(got, want) {
+				t.Errorf("got %v want %v\n", got, want)
+			}
+		})
+	}
+}
+
+func TestFanInOrdered(t *testing.T) {
+	slow := func(yield func(int) bool) {
+		for _, i := range []int{1, 3, 5, 6} {
+			time.Sleep(time.Millisecond)
+			if !yield(i) {
+				return
+			}
+		}
+	}
+	got := slices.Collect(FanInOrdered(2, slow, slices.Values([]int{2, 4})))
+	if want := []int{1, 2, 3, 4, 5, 6}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v want %v\n", got, want)
+	}
+}
+
+func TestFanInEarlyBreak(t *testing.T) {
+	before := runtime.NumGoroutine()
+	for _, fanIn := range []func(uint, ...iter.Seq[int]) iter.Seq[int]{FanIn[int], FanInOrdered[int]} {
+		got := slices.Collect(Take(fanIn(1, Repeat(1), Repeat(1), Repeat(1)), 10))
+		if len(got) != 10 {
+			t.Errorf("got %v want %v\n", len(got), 10)
+		}
+	}
+	if after := runtime.NumGoroutine(); after > before {
+		t.Errorf("goroutines leaked: before %v after %v\n", before, after)
+	}
+}
+
+func TestFanInPanic(t *testing.T) {
+	for _, fanIn := range []func(uint, ...iter.Seq[int]) iter.Seq[int]{FanIn[int], FanInOrdered[int]} {
+		func() {
+			defer func() {
+				if p := recover(); p != "boom" {
+					t.Errorf("got %v want %v\n", p, "boom")
+				}
+			}()
+			failing := func(yield func(int) bool) {
+				yield(1)
+				panic("boom")
+			}
+			Count(fanIn(0, failing, Repeat(2)))
+		}()
+	}
+}
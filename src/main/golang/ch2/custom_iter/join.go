@@ -0,0 +1,131 @@
+package custom_iter
+
+import (
+	"examples/ch2/custom_set"
+	"fmt"
+	"iter"
+	"slices"
+)
+
+type JoinMode int
+
+const (
+	// InnerJoin yields only the pairs of matching elements.
+	InnerJoin JoinMode = iota
+	// LeftOuterJoin also yields the left elements matching nothing.
+	LeftOuterJoin
+	// FullOuterJoin also yields the left and right elements matching nothing.
+	FullOuterJoin
+)
+
+func (m JoinMode) String() string {
+	switch m {
+	case InnerJoin:
+		return "inner"
+	case LeftOuterJoin:
+		return "left-outer"
+	case FullOuterJoin:
+		return "full-outer"
+	}
+	return fmt.Sprintf("JoinMode(%d)", int(m))
+}
+
+// hashTable indexes the right side of a join by key, keeping the right elements in their original order.
+type hashTable[R any, K comparable] struct {
+	rights  []R
+	indices map[K][]int
+}
+
+func buildHashTable[R any, K comparable](right iter.Seq[R], rightKey func(R) K) hashTable[R, K] {
+	table := hashTable[R, K]{indices: make(map[K][]int)}
+	for r := range right {
+		k := rightKey(r)
+		table.indices[k] = append(table.indices[k], len(table.rights))
+		table.rights = append(table.rights, r)
+	}
+	return table
+}
+
+// HashJoin pairs up the elements of left and right with equal keys. right is collected into a hash table first,
+// then left is streamed: each left element is yielded with its matches in the order of right.
+// Under FullOuterJoin, the right elements matching nothing are yielded last, in the order of right.
+func HashJoin[L, R any, K comparable](
+	left iter.Seq[L], right iter.Seq[R], leftKey func(L) K, rightKey func(R) K, mode JoinMode,
+) iter.Seq[EitherOrBoth[L, R]] {
+	return func(yield func(EitherOrBoth[L, R]) bool) {
+		table := buildHashTable(right, rightKey)
+		var matched []bool
+		if mode == FullOuterJoin {
+			matched = make([]bool, len(table.rights))
+		}
+		for l := range left {
+			indices := table.indices[leftKey(l)]
+			if len(indices) == 0 && mode != InnerJoin {
+				if !yield(EitherOrBoth[L, R]{Left: l, HasLeft: true}) {
+					return
+				}
+				continue
+			}
+			for _, i := range indices {
+				if matched != nil {
+					matched[i] = true
+				}
+				if !yield(EitherOrBoth[L, R]{Left: l, Right: table.rights[i], HasLeft: true, HasRight: true}) {
+					return
+				}
+			}
+		}
+		for i, ok := range matched {
+			if !ok && !yield(EitherOrBoth[L, R]{Right: table.rights[i], HasRight: true}) {
+				return
+			}
+		}
+	}
+}
+
+// GroupJoin pairs each left element with all the right elements of equal key, in the order of right.
+// A left element matching nothing is paired with nil. Left elements of equal key share the same backing array, so the
+// elements must not be modified in place; the slice is clipped, so appending to it copies.
+func GroupJoin[L, R any, K comparable](
+	left iter.Seq[L], right iter.Seq[R], leftKey func(L) K, rightKey func(R) K,
+) iter.Seq2[L, []R] {
+	return func(yield func(L, []R) bool) {
+		groups := make(map[K][]R)
+		for r := range right {
+			k := rightKey(r)
+			groups[k] = append(groups[k], r)
+		}
+		for l := range left {
+			if !yield(l, slices.Clip(groups[leftKey(l)])) {
+				return
+			}
+		}
+	}
+}
+
+// SemiJoin yields the left elements whose key is the key of some right element.
+func SemiJoin[L, R any, K comparable](
+	left iter.Seq[L], right iter.Seq[R], leftKey func(L) K, rightKey func(R) K,
+) iter.Seq[L] {
+	return filterByKeys(left, right, leftKey, rightKey, true)
+}
+
+// AntiJoin yields the left elements whose key is the key of no right element.
+func AntiJoin[L, R any, K comparable](
+	left iter.Seq[L], right iter.Seq[R], leftKey func(L) K, rightKey func(R) K,
+) iter.Seq[L] {
+	return filterByKeys(left, right, leftKey, rightKey, false)
+}
+
+func filterByKeys[L, R any, K comparable](
+	left iter.Seq[L], right iter.Seq[R], leftKey func(L) K, rightKey func(R) K, keep bool,
+) iter.Seq[L] {
+	return func(yield func(L) bool) {
+		keys := custom_set.Collect(Map(right, rightKey))
+		for l := range left {
+			if keys.Contains(leftKey(l)) == keep && !yield(l) {
+				return
+			}
+		}
+	}
+}
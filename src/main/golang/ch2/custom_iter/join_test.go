@@ -0,0 +1,89 @@
+package custom_iter
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+type employee struct {
+	name string
+	dept int
+}
+
+type department struct {
+	id   int
+	name string
+}
+
+var (
+	employees = []employee{{"ann", 1}, {"bob", 2}, {"cid", 1}, {"dan", 4}}
+	depts     = []department{{1, "eng"}, {2, "ops"}, {3, "hr"}, {1, "eng-2"}}
+)
+
+func empDept(e employee) int  { return e.dept }
+func deptID(d department) int { return d.id }
+
+func TestHashJoin(t *testing.T) {
+	for _, tc := range []struct {
+		mode JoinMode
+		want []string
+	}{
+		{InnerJoin, []string{"ann eng", "ann eng-2", "bob ops", "cid eng", "cid eng-2"}},
+		{LeftOuterJoin, []string{"ann eng", "ann eng-2", "bob ops", "cid eng", "cid eng-2", "dan -"}},
+		{FullOuterJoin, []string{"ann eng", "ann eng-2", "bob ops", "cid eng", "cid eng-2", "dan -", "- hr"}},
+	} {
+		var got []string
+		for e := range HashJoin(slices.Values(employees), slices.Values(depts), empDept, deptID, tc.mode) {
+			l, r := "-", "-"
+			if e.HasLeft {
+				l = e.Left.name
+			}
+			if e.HasRight {
+				r = e.Right.name
+			}
+			got = append(got, l+" "+r)
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%v got %v want %v\n", tc.mode, got, tc.want)
+		}
+	}
+}
+
+func TestGroupJoin(t *testing.T) {
+	var got []string
+	for e, ds := range GroupJoin(slices.Values(employees), slices.Values(depts), empDept, deptID) {
+		got = append(got, fmt.Sprint(e.name, len(ds)))
+	}
+	if want := []string{"ann2", "bob1", "cid2", "dan0"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v want %v\n", got, want)
+	}
+
+	left := []employee{{"ann", 1}, {"cid", 1}, {"dan", 4}}
+	right := []department{{1, "a"}, {1, "b"}, {1, "c"}}
+	var groups [][]department
+	for e, ds := range GroupJoin(slices.Values(left), slices.Values(right), empDept, deptID) {
+		if e.name == "dan" && ds != nil {
+			t.Errorf("got %v for %v want nil\n", ds, e.name)
+		}
+		groups = append(groups, append(ds, department{name: e.name}))
+	}
+	for i, ds := range groups {
+		if got := ds[len(ds)-1].name; got != left[i].name {
+			t.Errorf("got %v want %v\n", got, left[i].name)
+		}
+	}
+}
+
+func TestSemiAntiJoin(t *testing.T) {
+	name := func(e employee) string { return e.name }
+	semi := slices.Collect(Map(SemiJoin(slices.Values(employees), slices.Values(depts), empDept, deptID), name))
+	if want := []string{"ann", "bob", "cid"}; !reflect.DeepEqual(semi, want) {
+		t.Errorf("SemiJoin got %v want %v\n", semi, want)
+	}
+	anti := slices.Collect(Map(AntiJoin(slices.Values(employees), slices.Values(depts), empDept, deptID), name))
+	if want := []string{"dan"}; !reflect.DeepEqual(anti, want) {
+		t.Errorf("AntiJoin got %v want %v\n", anti, want)
+	}
+}
@@ -0,0 +1,156 @@
+package custom_iter
+
+import (
+	"container/heap"
+	"iter"
+)
+
+type mergeHead[T any] struct {
+	value T
+	index int
+}
+
+type mergeHeap[T any] struct {
+	heads     []mergeHead[T]
+	compareFn func(T, T) int
+}
+
+func (h *mergeHeap[T]) Len() int {
+	return len(h.heads)
+}
+
+// Less breaks ties by source index so Merge is stable.
+func (h *mergeHeap[T]) Less(i, j int) bool {
+	if comp := h.compareFn(h.heads[i].value, h.heads[j].value); comp != 0 {
+		return comp < 0
+	}
+	return h.heads[i].index < h.heads[j].index
+}
+
+func (h *mergeHeap[T]) Swap(i, j int) {
+	h.heads[i], h.heads[j] = h.heads[j], h.heads[i]
+}
+
+func (h *mergeHeap[T]) Push(x any) {
+	h.heads = append(h.heads, x.(mergeHead[T]))
+}
+
+func (h *mergeHeap[T]) Pop() any {
+	last := h.heads[len(h.heads)-1]
+	h.heads = h.heads[:len(h.heads)-1]
+	return last
+}
+
+// Merge k-way merges sequences that are already sorted by compareFn.
+func Merge[T any](compareFn func(T, T) int, seqs ...iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		nexts := make([]func() (T, bool), len(seqs))
+		h := &mergeHeap[T]{compareFn: compareFn}
+		for i, seq := range seqs {
+			next, stop := iter.Pull(seq)
+			defer stop()
+			nexts[i] = next
+			if t, ok := next(); ok {
+				h.heads = append(h.heads, mergeHead[T]{t, i})
+			}
+		}
+		heap.Init(h)
+		for h.Len() > 0 {
+			head := h.heads[0]
+			if !yield(head.value) {
+				return
+			}
+			if t, ok := nexts[head.index](); ok {
+				h.heads[0].value = t
+				heap.Fix(h, 0)
+			} else {
+				heap.Pop(h)
+			}
+		}
+	}
+}
+
+// Interleave yields one element from each sequence in turn, skipping the exhausted ones.
+func Interleave[T any](seqs ...iter.Seq[T]) iter.Seq[T] {
+	return interleave(false, seqs)
+}
+
+// InterleaveShortest stops as soon as the sequence whose turn it is runs out.
+func InterleaveShortest[T any](seqs ...iter.Seq[T]) iter.Seq[T] {
+	return interleave(true, seqs)
+}
+
+func interleave[T any](shortest bool, seqs []iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		nexts := make([]func() (T, bool), 0, len(seqs))
+		for _, seq := range seqs {
+			next, stop := iter.Pull(seq)
+			defer stop()
+			nexts = append(nexts, next)
+		}
+		for len(nexts) > 0 {
+			live := nexts[:0]
+			for _, next := range nexts {
+				t, ok := next()
+				if !ok {
+					if shortest {
+						return
+					}
+					continue
+				}
+				live = append(live, next)
+				if !yield(t) {
+					return
+				}
+			}
+			nexts = live
+		}
+	}
+}
+
+// EitherOrBoth is an element of MergeJoinBy and HashJoin. At least one of HasLeft and HasRight is true.
+type EitherOrBoth[L, R any] struct {
+	Left     L
+	Right    R
+	HasLeft  bool
+	HasRight bool
+}
+
+// MergeJoinBy walks two sequences sorted by compareFn and pairs up the elements comparing equal.
+func MergeJoinBy[L, R any](left iter.Seq[L], right iter.Seq[R], compareFn func(L, R) int) iter.Seq[EitherOrBoth[L, R]] {
+	return func(yield func(EitherOrBoth[L, R]) bool) {
+		nextLeft, stopLeft := iter.Pull(left)
+		defer stopLeft()
+		nextRight, stopRight := iter.Pull(right)
+		defer stopRight()
+		l, okLeft := nextLeft()
+		r, okRight := nextRight()
+		for okLeft || okRight {
+			var e EitherOrBoth[L, R]
+			comp := 0
+			switch {
+			case !okRight:
+				comp = -1
+			case !okLeft:
+				comp = 1
+			default:
+				comp = compareFn(l, r)
+			}
+			if comp <= 0 {
+				e.Left, e.HasLeft = l, true
+			}
+			if comp >= 0 {
+				e.Right, e.HasRight = r, true
+			}
+			if !yield(e) {
+				return
+			}
+			if e.HasLeft {
+				l, okLeft = nextLeft()
+			}
+			if e.HasRight {
+				r, okRight = nextRight()
+			}
+		}
+	}
+}
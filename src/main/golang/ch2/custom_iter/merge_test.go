@@ -0,0 +1,67 @@
+package custom_iter
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestMerge(t *testing.T) {
+	tcs := []struct {
+		inputs [][]int
+		want   []int
+	}{
+		{[][]int{{1, 4, 7}, {2, 5, 8}, {3, 6, 9}}, []int{1, 2, 3, 4, 5, 6, 7, 8, 9}},
+		{[][]int{{1, 1, 5}, {}, {0, 1, 10, 11}}, []int{0, 1, 1, 1, 5, 10, 11}},
+		{nil, nil},
+	}
+	for _, tc := range tcs {
+		t.Run(fmt.Sprintf("merge %v", tc.inputs), func(t *testing.T) {
+			seqs := slices.Collect(Map(slices.Values(tc.inputs), slices.Values[[]int]))
+			got := slices.Collect(Merge(cmp.Compare[int], seqs...))
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v want %v\n", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestMergeEarlyBreak(t *testing.T) {
+	got := slices.Collect(Take(Merge(cmp.Compare[int], Range(0, 100, 2).All(), Range(1, 100, 2).All()), 5))
+	if !reflect.DeepEqual(got, []int{0, 1, 2, 3, 4}) {
+		t.Errorf("got %v want %v\n", got, []int{0, 1, 2, 3, 4})
+	}
+}
+
+func TestInterleave(t *testing.T) {
+	seqs := []iter.Seq[int]{slices.Values([]int{1, 4}), slices.Values([]int{2}), slices.Values([]int{3, 5, 6})}
+	if got := slices.Collect(Interleave(seqs...)); !reflect.DeepEqual(got, []int{1, 2, 3, 4, 5, 6}) {
+		t.Errorf("got %v want %v\n", got, []int{1, 2, 3, 4, 5, 6})
+	}
+	if got := slices.Collect(InterleaveShortest(seqs...)); !reflect.DeepEqual(got, []int{1, 2, 3, 4}) {
+		t.Errorf("got %v want %v\n", got, []int{1, 2, 3, 4})
+	}
+}
+
+func TestMergeJoinBy(t *testing.T) {
+	left := slices.Values([]int{1, 2, 4})
+	right := slices.Values([]string{"2", "3", "4", "5"})
+	var got []string
+	for e := range MergeJoinBy(left, right, func(l int, r string) int { return cmp.Compare(fmt.Sprint(l), r) }) {
+		switch {
+		case e.HasLeft && e.HasRight:
+			got = append(got, fmt.Sprintf("both %v %v", e.Left, e.Right))
+		case e.HasLeft:
+			got = append(got, fmt.Sprintf("left %v", e.Left))
+		default:
+			got = append(got, fmt.Sprintf("right %v", e.Right))
+		}
+	}
+	want := []string{"left 1", "both 2 2", "right 3", "both 4 4", "right 5"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v want %v\n", got, want)
+	}
+}
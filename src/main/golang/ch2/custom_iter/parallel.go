@@ -0,0 +1,185 @@
+package custom_iter
+
+import (
+	"iter"
+	"sync"
+)
+
+// ParMap runs mapFn on workers goroutines while at most window elements are in flight.
+// Results are yielded in input order. The source sequence is driven on its own goroutine.
+// Breaking out of the loop stops every goroutine before the sequence returns,
+// and a panic in mapFn or in the source is re-raised on the consumer.
+func ParMap[T, R any](it iter.Seq[T], workers, window uint, mapFn func(T) R) iter.Seq[R] {
+	return parFilterMap(it, workers, window, true, func(t T) (R, bool) {
+		return mapFn(t), true
+	})
+}
+
+func ParFilter[T any](it iter.Seq[T], workers, window uint, pred func(T) bool) iter.Seq[T] {
+	return parFilterMap(it, workers, window, true, func(t T) (T, bool) {
+		return t, pred(t)
+	})
+}
+
+func ParFilterMap[T, R any](it iter.Seq[T], workers, window uint, filterMapFn func(T) (R, bool)) iter.Seq[R] {
+	return parFilterMap(it, workers, window, true, filterMapFn)
+}
+
+// ParMapUnordered is ParMap that yields results as soon as they are ready.
+func ParMapUnordered[T, R any](it iter.Seq[T], workers, window uint, mapFn func(T) R) iter.Seq[R] {
+	return parFilterMap(it, workers, window, false, func(t T) (R, bool) {
+		return mapFn(t), true
+	})
+}
+
+func ParFilterUnordered[T any](it iter.Seq[T], workers, window uint, pred func(T) bool) iter.Seq[T] {
+	return parFilterMap(it, workers, window, false, func(t T) (T, bool) {
+		return t, pred(t)
+	})
+}
+
+func ParFilterMapUnordered[T, R any](it iter.Seq[T], workers, window uint, filterMapFn func(T) (R, bool)) iter.Seq[R] {
+	return parFilterMap(it, workers, window, false, filterMapFn)
+}
+
+type parResult[R any] struct {
+	value     R
+	ok        bool
+	panicked  bool
+	panicInfo any
+}
+
+type parJob[T, R any] struct {
+	value T
+	slot  chan parResult[R]
+}
+
+func parFilterMap[T, R any](it iter.Seq[T], workers, window uint, ordered bool, filterMapFn func(T) (R, bool)) iter.Seq[R] {
+	if workers == 0 {
+		panic("parallel workers cannot be zero")
+	}
+	if window == 0 {
+		panic("parallel window cannot be zero")
+	}
+	return func(yield func(R) bool) {
+		done := make(chan struct{})
+		sem := make(chan struct{}, window)
+		jobs := make(chan parJob[T, R])
+		// ordered mode queues one slot per element, unordered mode shares a single slot
+		pending := make(chan chan parResult[R], window)
+		shared := make(chan parResult[R], window)
+		var wg, workerWg sync.WaitGroup
+		defer func() {
+			close(done)
+			wg.Wait()
+		}()
+
+		send := func(slot chan parResult[R], res parResult[R]) {
+			select {
+			case slot <- res:
+			case <-done:
+			}
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() {
+				if p := recover(); p != nil {
+					res := parResult[R]{panicked: true, panicInfo: p}
+					if ordered {
+						slot := make(chan parResult[R], 1)
+						slot <- res
+						select {
+						case pending <- slot:
+						case <-done:
+						}
+					} else {
+						send(shared, res)
+					}
+				}
+				close(jobs)
+				if ordered {
+					close(pending)
+				}
+			}()
+			for t := range it {
+				select {
+				case sem <- struct{}{}:
+				case <-done:
+					return
+				}
+				slot := shared
+				if ordered {
+					slot = make(chan parResult[R], 1)
+				}
+				select {
+				case jobs <- parJob[T, R]{t, slot}:
+				case <-done:
+					return
+				}
+				if ordered {
+					select {
+					case pending <- slot:
+					case <-done:
+						return
+					}
+				}
+			}
+		}()
+
+		for range workers {
+			wg.Add(1)
+			workerWg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer workerWg.Done()
+				for job := range jobs {
+					send(job.slot, parApply(filterMapFn, job.value))
+				}
+			}()
+		}
+
+		if !ordered {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				workerWg.Wait()
+				close(shared)
+			}()
+			for res := range shared {
+				if !parYield(res, sem, yield) {
+					return
+				}
+			}
+			return
+		}
+
+		for slot := range pending {
+			if !parYield(<-slot, sem, yield) {
+				return
+			}
+		}
+	}
+}
+
+func parApply[T, R any](filterMapFn func(T) (R, bool), t T) (res parResult[R]) {
+	defer func() {
+		if p := recover(); p != nil {
+			res = parResult[R]{panicked: true, panicInfo: p}
+		}
+	}()
+	res.value, res.ok = filterMapFn(t)
+	return
+}
+
+func parYield[R any](res parResult[R], sem chan struct{}, yield func(R) bool) bool {
+	if res.panicked {
+		panic(res.panicInfo)
+	}
+	<-sem
+	if !res.ok {
+		return true
+	}
+	return yield(res.value)
+}
@@ -0,0 +1,96 @@
+package custom_iter
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestParMap(t *testing.T) {
+	tcs := []struct {
+		input           []int
+		workers, window uint
+		want            []int
+	}{
+		{[]int{1, 2, 3, 4, 5, 6, 7, 8}, 3, 4, []int{2, 4, 6, 8, 10, 12, 14, 16}},
+		{[]int{5, 4, 3, 2, 1}, 1, 1, []int{10, 8, 6, 4, 2}},
+		{nil, 2, 2, nil},
+	}
+	for _, tc := range tcs {
+		t.Run(fmt.Sprintf("par map %v %v %v", tc.input, tc.workers, tc.window), func(t *testing.T) {
+			got := slices.Collect(ParMap(slices.Values(tc.input), tc.workers, tc.window, func(i int) int {
+				time.Sleep(time.Duration(i) * time.Millisecond)
+				return i * 2
+			}))
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v want %v\n", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParFilter(t *testing.T) {
+	got := slices.Collect(ParFilter(slices.Values([]int{1, 2, 3, 4, 5, 6}), 4, 2, func(i int) bool {
+		return i%2 == 0
+	}))
+	want := []int{2, 4, 6}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v want %v\n", got, want)
+	}
+}
+
+func TestParFilterMapUnordered(t *testing.T) {
+	got := slices.Collect(ParFilterMapUnordered(slices.Values([]int{1, 2, 3, 4, 5, 6}), 3, 3, func(i int) (string, bool) {
+		return fmt.Sprint(i), i > 2
+	}))
+	slices.Sort(got)
+	want := []string{"3", "4", "5", "6"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v want %v\n", got, want)
+	}
+}
+
+func TestParMapEarlyBreak(t *testing.T) {
+	before := runtime.NumGoroutine()
+	for _, it := range []func(yield func(int) bool){
+		ParMap(Cycle(slices.Values([]int{1, 2, 3})), 4, 8, func(i int) int { return i }),
+		ParMapUnordered(Cycle(slices.Values([]int{1, 2, 3})), 4, 8, func(i int) int { return i }),
+	} {
+		count := 0
+		for range it {
+			count++
+			if count == 10 {
+				break
+			}
+		}
+	}
+	if after := runtime.NumGoroutine(); after > before {
+		t.Errorf("goroutines leaked: before %v after %v\n", before, after)
+	}
+}
+
+func TestParMapPanic(t *testing.T) {
+	for _, ordered := range []bool{true, false} {
+		t.Run(fmt.Sprintf("par map panic ordered %v", ordered), func(t *testing.T) {
+			defer func() {
+				if p := recover(); p != "boom" {
+					t.Errorf("got %v want %v\n", p, "boom")
+				}
+			}()
+			mapFn := func(i int) int {
+				if i == 3 {
+					panic("boom")
+				}
+				return i
+			}
+			if ordered {
+				ForEach(ParMap(slices.Values([]int{1, 2, 3, 4}), 2, 2, mapFn), func(int) {})
+			} else {
+				ForEach(ParMapUnordered(slices.Values([]int{1, 2, 3, 4}), 2, 2, mapFn), func(int) {})
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package custom_iter
+
+import "iter"
+
+// PeekableIter is a single-pass cursor over a sequence driven by iter.Pull.
+// Peeked elements are buffered, so looking ahead never replays the source.
+// Stop must be called if the cursor is abandoned before it is exhausted.
+type PeekableIter[T any] struct {
+	next func() (T, bool)
+	stop func()
+	buf  []T
+	done bool
+}
+
+func Peekable[T any](it iter.Seq[T]) *PeekableIter[T] {
+	next, stop := iter.Pull(it)
+	return &PeekableIter[T]{next: next, stop: stop}
+}
+
+func (p *PeekableIter[T]) fill(n int) bool {
+	for len(p.buf) < n {
+		if p.done {
+			return false
+		}
+		t, ok := p.next()
+		if !ok {
+			p.done = true
+			p.stop()
+			return false
+		}
+		p.buf = append(p.buf, t)
+	}
+	return true
+}
+
+func (p *PeekableIter[T]) Next() (T, bool) {
+	var zero T
+	if !p.fill(1) {
+		return zero, false
+	}
+	t := p.buf[0]
+	p.buf[0] = zero
+	p.buf = p.buf[1:]
+	return t, true
+}
+
+func (p *PeekableIter[T]) Peek() (T, bool) {
+	if !p.fill(1) {
+		var zero T
+		return zero, false
+	}
+	return p.buf[0], true
+}
+
+// PeekN returns up to n upcoming elements without consuming them.
+// The returned slice is only valid until the next call on the cursor.
+func (p *PeekableIter[T]) PeekN(n uint) []T {
+	p.fill(int(n))
+	n = min(n, uint(len(p.buf)))
+	return p.buf[:n:n]
+}
+
+func (p *PeekableIter[T]) NextIf(pred func(T) bool) (T, bool) {
+	t, ok := p.Peek()
+	if !ok || !pred(t) {
+		var zero T
+		return zero, false
+	}
+	return p.Next()
+}
+
+func NextIfEq[T comparable](p *PeekableIter[T], expected T) (T, bool) {
+	return p.NextIf(func(t T) bool {
+		return t == expected
+	})
+}
+
+// All yields the remaining elements, including peeked ones.
+func (p *PeekableIter[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for {
+			t, ok := p.Next()
+			if !ok {
+				return
+			}
+			if !yield(t) {
+				return
+			}
+		}
+	}
+}
+
+func (p *PeekableIter[T]) Stop() {
+	p.done = true
+	p.buf = nil
+	p.stop()
+}
@@ -0,0 +1,68 @@
+package custom_iter
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+	"unicode"
+)
+
+func TestPeekable(t *testing.T) {
+	pulls := 0
+	p := Peekable(Inspect(slices.Values([]int{1, 2, 3, 4}), func(int) { pulls++ }))
+	defer p.Stop()
+	if got, ok := p.Peek(); got != 1 || !ok {
+		t.Errorf("got %v %v want %v %v\n", got, ok, 1, true)
+	}
+	if got, ok := p.Peek(); got != 1 || !ok || pulls != 1 {
+		t.Errorf("got %v %v %v want %v %v %v\n", got, ok, pulls, 1, true, 1)
+	}
+	if got := p.PeekN(3); !reflect.DeepEqual(got, []int{1, 2, 3}) {
+		t.Errorf("got %v want %v\n", got, []int{1, 2, 3})
+	}
+	if got, ok := p.Next(); got != 1 || !ok {
+		t.Errorf("got %v %v want %v %v\n", got, ok, 1, true)
+	}
+	if got, ok := p.NextIf(func(i int) bool { return i > 5 }); got != 0 || ok {
+		t.Errorf("got %v %v want %v %v\n", got, ok, 0, false)
+	}
+	if got, ok := NextIfEq(p, 2); got != 2 || !ok {
+		t.Errorf("got %v %v want %v %v\n", got, ok, 2, true)
+	}
+	if got := p.PeekN(5); !reflect.DeepEqual(got, []int{3, 4}) {
+		t.Errorf("got %v want %v\n", got, []int{3, 4})
+	}
+	if got := slices.Collect(p.All()); !reflect.DeepEqual(got, []int{3, 4}) || pulls != 4 {
+		t.Errorf("got %v %v want %v %v\n", got, pulls, []int{3, 4}, 4)
+	}
+	if got, ok := p.Next(); got != 0 || ok {
+		t.Errorf("got %v %v want %v %v\n", got, ok, 0, false)
+	}
+}
+
+func TestPeekableTokenize(t *testing.T) {
+	p := Peekable(slices.Values([]rune("12+345")))
+	defer p.Stop()
+	var tokens []string
+	for {
+		r, ok := p.Next()
+		if !ok {
+			break
+		}
+		token := []rune{r}
+		if unicode.IsDigit(r) {
+			for {
+				d, ok := p.NextIf(unicode.IsDigit)
+				if !ok {
+					break
+				}
+				token = append(token, d)
+			}
+		}
+		tokens = append(tokens, string(token))
+	}
+	want := []string{"12", "+", "345"}
+	if !reflect.DeepEqual(tokens, want) {
+		t.Errorf("got %v want %v\n", tokens, want)
+	}
+}
@@ -0,0 +1,226 @@
+package custom_iter
+
+import (
+	"examples/ch2/custom_set"
+	"iter"
+	"math"
+	"slices"
+)
+
+// SizeHint bounds the number of remaining elements like size_hint in Rust.
+// Upper is only meaningful when Bounded is true.
+type SizeHint struct {
+	Lower   uint
+	Upper   uint
+	Bounded bool
+}
+
+func ExactSize(n uint) SizeHint {
+	return SizeHint{n, n, true}
+}
+
+func (hint SizeHint) Exact() bool {
+	return hint.Bounded && hint.Lower == hint.Upper
+}
+
+// capacity is the preallocation size a collector should reserve. Like Rust collections, it trusts only the lower
+// bound: an upper bound such as the n of SizedTake over an unknown source may be far above the actual length.
+func (hint SizeHint) capacity() uint {
+	return hint.Lower
+}
+
+// Sized is implemented by sequences carrying a SizeHint, like SizedSeq and DoubleEndedSeq.
+type Sized[T any] interface {
+	All() iter.Seq[T]
+	SizeHint() SizeHint
+}
+
+// Sized2 is Sized for iter.Seq2.
+type Sized2[K, V any] interface {
+	All() iter.Seq2[K, V]
+	SizeHint() SizeHint
+}
+
+// SizedSeq is an iter.Seq carrying a SizeHint. Range over All.
+type SizedSeq[T any] struct {
+	seq  iter.Seq[T]
+	hint SizeHint
+}
+
+type SizedSeq2[K, V any] struct {
+	seq  iter.Seq2[K, V]
+	hint SizeHint
+}
+
+func WithSizeHint[T any](it iter.Seq[T], hint SizeHint) SizedSeq[T] {
+	return SizedSeq[T]{it, hint}
+}
+
+func WithSizeHint2[K, V any](it iter.Seq2[K, V], hint SizeHint) SizedSeq2[K, V] {
+	return SizedSeq2[K, V]{it, hint}
+}
+
+func (s SizedSeq[T]) All() iter.Seq[T] {
+	return s.seq
+}
+
+func (s SizedSeq[T]) SizeHint() SizeHint {
+	return s.hint
+}
+
+func (s SizedSeq2[K, V]) All() iter.Seq2[K, V] {
+	return s.seq
+}
+
+func (s SizedSeq2[K, V]) SizeHint() SizeHint {
+	return s.hint
+}
+
+func SizedMap[T, R any](it Sized[T], mapFn func(T) R) SizedSeq[R] {
+	return WithSizeHint(Map(it.All(), mapFn), it.SizeHint())
+}
+
+func SizedTake[T any](it Sized[T], n uint) SizedSeq[T] {
+	hint := it.SizeHint()
+	hint.Lower = min(hint.Lower, n)
+	if hint.Bounded {
+		hint.Upper = min(hint.Upper, n)
+	} else {
+		hint.Upper, hint.Bounded = n, true
+	}
+	return WithSizeHint(Take(it.All(), n), hint)
+}
+
+func SizedSkip[T any](it Sized[T], n uint) SizedSeq[T] {
+	hint := it.SizeHint()
+	hint.Lower = hint.Lower - min(hint.Lower, n)
+	hint.Upper = hint.Upper - min(hint.Upper, n)
+	return WithSizeHint(Skip(it.All(), n), hint)
+}
+
+func SizedStepBy[T any](it Sized[T], step uint) SizedSeq[T] {
+	hint := it.SizeHint()
+	stepped := StepBy(it.All(), step)
+	hint.Lower = ceilDiv(hint.Lower, step)
+	hint.Upper = ceilDiv(hint.Upper, step)
+	return WithSizeHint(stepped, hint)
+}
+
+func SizedChain[T any](it, other Sized[T]) SizedSeq[T] {
+	hint, otherHint := it.SizeHint(), other.SizeHint()
+	hint.Lower = saturatingAdd(hint.Lower, otherHint.Lower)
+	hint.Upper = saturatingAdd(hint.Upper, otherHint.Upper)
+	hint.Bounded = hint.Bounded && otherHint.Bounded && hint.Upper != math.MaxUint
+	return WithSizeHint(Chain(it.All(), other.All()), hint)
+}
+
+// ceilDiv rounds up without the overflow of (n + d - 1) / d when n is near math.MaxUint.
+func ceilDiv(n, d uint) uint {
+	return n/d + min(1, n%d)
+}
+
+func saturatingAdd(a, b uint) uint {
+	if a > math.MaxUint-b {
+		return math.MaxUint
+	}
+	return a + b
+}
+
+func SizedZip[T, O any](it Sized[T], other Sized[O]) SizedSeq2[T, O] {
+	hint, otherHint := it.SizeHint(), other.SizeHint()
+	hint.Lower = min(hint.Lower, otherHint.Lower)
+	switch {
+	case hint.Bounded && otherHint.Bounded:
+		hint.Upper = min(hint.Upper, otherHint.Upper)
+	case otherHint.Bounded:
+		hint.Upper, hint.Bounded = otherHint.Upper, true
+	}
+	return WithSizeHint2(Zip(it.All(), other.All()), hint)
+}
+
+func SizedEnumerate[T any](it Sized[T]) SizedSeq2[uint, T] {
+	return WithSizeHint2(Enumerate(it.All()), it.SizeHint())
+}
+
+// SizedRev reads RandomAccess sequences backwards, and buffers any other sequence into a slice preallocated from
+// the hint.
+func SizedRev[T any](it Sized[T]) SizedSeq[T] {
+	hint := it.SizeHint()
+	if src, ok := it.(RandomAccess[T]); ok {
+		return WithSizeHint(func(yield func(T) bool) {
+			for i := src.Len(); i > 0; i-- {
+				if !yield(src.At(i - 1)) {
+					return
+				}
+			}
+		}, hint)
+	}
+	return WithSizeHint(func(yield func(T) bool) {
+		temp := CollectSized(it, SliceCollector[T]{})
+		for i := len(temp) - 1; i >= 0; i-- {
+			if !yield(temp[i]) {
+				return
+			}
+		}
+	}, hint)
+}
+
+// CollectSized is Collect reserving room from the size hint of it when the collector implements Reserver.
+func CollectSized[T, C any](it Sized[T], collector Collector[T, C]) C {
+	return collector.Extend(reserve(collector, collector.New(), it.SizeHint()), it.All())
+}
+
+func CollectSized2[K, V, C any](it Sized2[K, V], collector Collector2[K, V, C]) C {
+	c := collector.New()
+	if reserver, ok := collector.(Reserver[C]); ok {
+		if hint := it.SizeHint(); hint.capacity() > 0 {
+			c = reserver.Reserve(c, hint.capacity())
+		}
+	}
+	return collector.Extend(c, it.All())
+}
+
+// PartitionSized is Partition reserving room for both halves from the size hint of it.
+func PartitionSized[T, C any](it Sized[T], pred func(T) bool, collector Collector[T, C]) (C, C) {
+	hint := it.SizeHint()
+	satisfies, rest := reserve(collector, collector.New(), hint), reserve(collector, collector.New(), hint)
+	return partitionInto(it.All(), pred, collector, satisfies, rest)
+}
+
+// Reserver is implemented by collectors that can preallocate room for n more elements.
+type Reserver[C any] interface {
+	Reserve(C, uint) C
+}
+
+func (SliceCollector[T]) Reserve(s []T, n uint) []T {
+	return slices.Grow(s, int(n))
+}
+
+func (MapCollector[K, V]) Reserve(m map[K]V, n uint) map[K]V {
+	if len(m) == 0 {
+		return make(map[K]V, n)
+	}
+	return m
+}
+
+func (SetCollector[T]) Reserve(set *custom_set.Set[T], n uint) *custom_set.Set[T] {
+	if set.Empty() {
+		grown := make(custom_set.Set[T], n)
+		return &grown
+	}
+	return set
+}
+
+func (CountCollector[T]) Reserve(m map[T]uint, n uint) map[T]uint {
+	if len(m) == 0 {
+		return make(map[T]uint, n)
+	}
+	return m
+}
+
+func reserve[T, C any](collector Collector[T, C], c C, hint SizeHint) C {
+	if reserver, ok := collector.(Reserver[C]); ok && hint.capacity() > 0 {
+		return reserver.Reserve(c, hint.capacity())
+	}
+	return c
+}
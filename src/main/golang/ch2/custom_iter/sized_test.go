@@ -0,0 +1,101 @@
+package custom_iter
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestSizeHintPropagation(t *testing.T) {
+	exact := FromSlice([]int{1, 2, 3, 4, 5, 6, 7})
+	unknown := WithSizeHint(slices.Values([]int{1, 2, 3}), SizeHint{})
+	tcs := []struct {
+		description string
+		got         SizeHint
+		want        SizeHint
+	}{
+		{"plain", unknown.SizeHint(), SizeHint{}},
+		{"double ended", exact.SizeHint(), ExactSize(7)},
+		{"map", SizedMap(exact, func(i int) string { return fmt.Sprint(i) }).SizeHint(), ExactSize(7)},
+		{"take", SizedTake(exact, 3).SizeHint(), ExactSize(3)},
+		{"take unknown", SizedTake(unknown, 3).SizeHint(), SizeHint{0, 3, true}},
+		{"skip", SizedSkip(exact, 10).SizeHint(), ExactSize(0)},
+		{"step by", SizedStepBy(exact, 3).SizeHint(), ExactSize(3)},
+		{"step by huge", SizedStepBy(WithSizeHint(Repeat(1), SizeHint{Lower: math.MaxUint}), 2).SizeHint(), SizeHint{Lower: math.MaxUint/2 + 1}},
+		{"chain", SizedChain(exact, exact).SizeHint(), ExactSize(14)},
+		{"chain unknown", SizedChain(exact, unknown).SizeHint(), SizeHint{7, 7, false}},
+		{"zip", SizedZip(unknown, SizedTake(exact, 2)).SizeHint(), SizeHint{0, 2, true}},
+		{"enumerate", SizedEnumerate(exact).SizeHint(), ExactSize(7)},
+		{"rev", SizedRev(SizedMap(exact, func(i int) int { return i })).SizeHint(), ExactSize(7)},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.description, func(t *testing.T) {
+			if tc.got != tc.want {
+				t.Errorf("got %v want %v\n", tc.got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSizedValues(t *testing.T) {
+	s := SizedRev(SizedStepBy(SizedMap(Range(0, 10, 1), func(i int) int { return i * i }), 3))
+	got := CollectSized(s, SliceCollector[int]{})
+	want := []int{81, 36, 9, 0}
+	if !reflect.DeepEqual(got, want) || cap(got) != 4 {
+		t.Errorf("got %v %v want %v %v\n", got, cap(got), want, 4)
+	}
+	evens, odds := PartitionSized(SizedTake(Range(0, 10, 1), 4), func(i int) bool { return i%2 == 0 }, SliceCollector[int]{})
+	if !reflect.DeepEqual(evens, []int{0, 2}) || !reflect.DeepEqual(odds, []int{1, 3}) {
+		t.Errorf("got %v %v want %v %v\n", evens, odds, []int{0, 2}, []int{1, 3})
+	}
+	m := CollectSized2(SizedEnumerate(FromString("ab")), MapCollector[uint, byte]{})
+	if !reflect.DeepEqual(m, map[uint]byte{0: 'a', 1: 'b'}) {
+		t.Errorf("got %v want %v\n", m, map[uint]byte{0: 'a', 1: 'b'})
+	}
+}
+
+func TestSizedUnknownSource(t *testing.T) {
+	unknown := WithSizeHint(slices.Values([]int{1, 2}), SizeHint{})
+	got := CollectSized(SizedTake(unknown, math.MaxUint), SliceCollector[int]{})
+	if !reflect.DeepEqual(got, []int{1, 2}) {
+		t.Errorf("got %v want %v\n", got, []int{1, 2})
+	}
+	filtered := WithSizeHint(Filter(slices.Values([]int{1, 2, 3, 4}), func(i int) bool { return i%2 == 0 }), SizeHint{})
+	got = CollectSized(SizedTake(filtered, 1<<26), SliceCollector[int]{})
+	if !reflect.DeepEqual(got, []int{2, 4}) || cap(got) > 4 {
+		t.Errorf("got %v with capacity %d want %v with a small capacity\n", got, cap(got), []int{2, 4})
+	}
+}
+
+const benchmarkSize = 1_000_000
+
+func BenchmarkCollectUnsized(b *testing.B) {
+	b.ReportAllocs()
+	for range b.N {
+		CollectIntoSlice(Map(Take(Cycle(slices.Values([]int{1, 2, 3})), benchmarkSize), func(i int) int { return i * 2 }))
+	}
+}
+
+func BenchmarkCollectSized(b *testing.B) {
+	b.ReportAllocs()
+	for range b.N {
+		cycle := WithSizeHint(Cycle(slices.Values([]int{1, 2, 3})), SizeHint{Lower: math.MaxUint})
+		CollectSized(SizedMap(SizedTake(cycle, benchmarkSize), func(i int) int { return i * 2 }), SliceCollector[int]{})
+	}
+}
+
+func BenchmarkCollectorUnsized(b *testing.B) {
+	b.ReportAllocs()
+	for range b.N {
+		Collect(Map(Range(0, benchmarkSize, 1).All(), func(i int) int { return i * 2 }), SliceCollector[int]{})
+	}
+}
+
+func BenchmarkCollectorSized(b *testing.B) {
+	b.ReportAllocs()
+	for range b.N {
+		CollectSized(SizedMap(Range(0, benchmarkSize, 1), func(i int) int { return i * 2 }), SliceCollector[int]{})
+	}
+}
@@ -0,0 +1,79 @@
+package custom_iter
+
+import "iter"
+
+func Empty[T any]() iter.Seq[T] {
+	return func(yield func(T) bool) {}
+}
+
+func Once[T any](t T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		yield(t)
+	}
+}
+
+// OnceWith calls makeFn lazily, only when the element is pulled.
+func OnceWith[T any](makeFn func() T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		yield(makeFn())
+	}
+}
+
+func Repeat[T any](t T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for yield(t) {
+		}
+	}
+}
+
+func RepeatN[T any](t T, n uint) DoubleEndedSeq[T] {
+	return newDoubleEndedSeq(n, func(uint) T {
+		return t
+	})
+}
+
+func RepeatWith[T any](makeFn func() T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for yield(makeFn()) {
+		}
+	}
+}
+
+// FromFn yields the results of nextFn until it reports false.
+func FromFn[T any](nextFn func() (T, bool)) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for {
+			t, ok := nextFn()
+			if !ok || !yield(t) {
+				return
+			}
+		}
+	}
+}
+
+// Successors yields first, then every successor computed from the previous element until succFn reports false.
+func Successors[T any](first T, succFn func(T) (T, bool)) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		t, ok := first, true
+		for ok {
+			if !yield(t) {
+				return
+			}
+			t, ok = succFn(t)
+		}
+	}
+}
+
+// Unfold threads a state through unfoldFn, yielding one element per step until unfoldFn reports false.
+func Unfold[S, T any](init S, unfoldFn func(S) (T, S, bool)) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		state := init
+		for {
+			t, next, ok := unfoldFn(state)
+			if !ok || !yield(t) {
+				return
+			}
+			state = next
+		}
+	}
+}
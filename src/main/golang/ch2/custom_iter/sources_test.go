@@ -0,0 +1,40 @@
+package custom_iter
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestSources(t *testing.T) {
+	calls := 0
+	counter := 0
+	countdown := 3
+	tcs := []struct {
+		description string
+		got         []int
+		want        []int
+	}{
+		{"empty", slices.Collect(Empty[int]()), nil},
+		{"once", slices.Collect(Once(1)), []int{1}},
+		{"once with", slices.Collect(OnceWith(func() int { calls++; return 2 })), []int{2}},
+		{"repeat", slices.Collect(Take(Repeat(7), 3)), []int{7, 7, 7}},
+		{"repeat n", CollectIntoSlice(RepeatN(8, 2).All()), []int{8, 8}},
+		{"repeat with", slices.Collect(Take(RepeatWith(func() int { counter++; return counter }), 3)), []int{1, 2, 3}},
+		{"from fn", slices.Collect(FromFn(func() (int, bool) { countdown--; return countdown, countdown > 0 })), []int{2, 1}},
+		{"successors", slices.Collect(Successors(1, func(i int) (int, bool) { return i * 3, i*3 < 100 })), []int{1, 3, 9, 27, 81}},
+		{"unfold", slices.Collect(Unfold([2]int{0, 1}, func(s [2]int) (int, [2]int, bool) {
+			return s[0], [2]int{s[1], s[0] + s[1]}, s[0] < 10
+		})), []int{0, 1, 1, 2, 3, 5, 8}},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.description, func(t *testing.T) {
+			if !reflect.DeepEqual(tc.got, tc.want) {
+				t.Errorf("got %v want %v\n", tc.got, tc.want)
+			}
+		})
+	}
+	if calls != 1 {
+		t.Errorf("got %v want %v\n", calls, 1)
+	}
+}
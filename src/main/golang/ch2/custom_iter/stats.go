@@ -0,0 +1,241 @@
+package custom_iter
+
+import (
+	"cmp"
+	"container/heap"
+	"iter"
+	"math"
+	"slices"
+)
+
+type boundedHeap[T any] struct {
+	elems []T
+	less  func(T, T) bool
+}
+
+func (h *boundedHeap[T]) Len() int {
+	return len(h.elems)
+}
+
+func (h *boundedHeap[T]) Less(i, j int) bool {
+	return h.less(h.elems[i], h.elems[j])
+}
+
+func (h *boundedHeap[T]) Swap(i, j int) {
+	h.elems[i], h.elems[j] = h.elems[j], h.elems[i]
+}
+
+func (h *boundedHeap[T]) Push(x any) {
+	h.elems = append(h.elems, x.(T))
+}
+
+func (h *boundedHeap[T]) Pop() any {
+	last := h.elems[len(h.elems)-1]
+	h.elems = h.elems[:len(h.elems)-1]
+	return last
+}
+
+// TopKBy keeps the k largest elements by compareFn in a heap of size k, so it needs O(k) memory.
+// The result is sorted from the largest to the smallest.
+func TopKBy[T any](it iter.Seq[T], k uint, compareFn func(T, T) int) []T {
+	if k == 0 {
+		return nil
+	}
+	h := &boundedHeap[T]{
+		elems: make([]T, 0, k),
+		less: func(a, b T) bool {
+			return compareFn(a, b) < 0
+		},
+	}
+	for t := range it {
+		if uint(h.Len()) < k {
+			heap.Push(h, t)
+		} else if compareFn(t, h.elems[0]) > 0 {
+			h.elems[0] = t
+			heap.Fix(h, 0)
+		}
+	}
+	slices.SortFunc(h.elems, func(a, b T) int {
+		return compareFn(b, a)
+	})
+	return h.elems
+}
+
+func LargestN[T cmp.Ordered](it iter.Seq[T], k uint) []T {
+	return TopKBy(it, k, cmp.Compare[T])
+}
+
+// SmallestN is sorted from the smallest to the largest.
+func SmallestN[T cmp.Ordered](it iter.Seq[T], k uint) []T {
+	return TopKBy(it, k, func(a, b T) int {
+		return cmp.Compare(b, a)
+	})
+}
+
+// RunningStats accumulates the mean and variance of a stream with Welford's algorithm.
+type RunningStats struct {
+	count uint
+	mean  float64
+	m2    float64
+}
+
+func (stats *RunningStats) Push(x float64) {
+	stats.count++
+	delta := x - stats.mean
+	stats.mean += delta / float64(stats.count)
+	stats.m2 += delta * (x - stats.mean)
+}
+
+func (stats *RunningStats) Count() uint {
+	return stats.count
+}
+
+func (stats *RunningStats) Mean() float64 {
+	return stats.mean
+}
+
+// Variance is the population variance.
+func (stats *RunningStats) Variance() float64 {
+	if stats.count == 0 {
+		return 0
+	}
+	return stats.m2 / float64(stats.count)
+}
+
+func (stats *RunningStats) SampleVariance() float64 {
+	if stats.count < 2 {
+		return 0
+	}
+	return stats.m2 / float64(stats.count-1)
+}
+
+func runningStats[T numeric](it iter.Seq[T]) RunningStats {
+	var stats RunningStats
+	for t := range it {
+		stats.Push(float64(t))
+	}
+	return stats
+}
+
+func Mean[T numeric](it iter.Seq[T]) (float64, bool) {
+	stats := runningStats(it)
+	return stats.Mean(), stats.Count() > 0
+}
+
+func Variance[T numeric](it iter.Seq[T]) (float64, bool) {
+	stats := runningStats(it)
+	return stats.Variance(), stats.Count() > 0
+}
+
+// P2Quantile estimates the p-quantile of a stream in O(1) memory with the P² algorithm by Jain & Chlamtac.
+// It is exact for up to five samples.
+type P2Quantile struct {
+	p         float64
+	count     uint
+	heights   [5]float64
+	positions [5]float64
+	desired   [5]float64
+	increment [5]float64
+}
+
+func NewP2Quantile(p float64) *P2Quantile {
+	if p < 0 || p > 1 {
+		panic("P2Quantile p must be in [0, 1]")
+	}
+	return &P2Quantile{
+		p:         p,
+		positions: [5]float64{1, 2, 3, 4, 5},
+		desired:   [5]float64{1, 1 + 2*p, 1 + 4*p, 3 + 2*p, 5},
+		increment: [5]float64{0, p / 2, p, (1 + p) / 2, 1},
+	}
+}
+
+func (q *P2Quantile) Push(x float64) {
+	if q.count < 5 {
+		q.heights[q.count] = x
+		q.count++
+		if q.count == 5 {
+			slices.Sort(q.heights[:])
+		}
+		return
+	}
+	q.count++
+	var k int
+	switch {
+	case x < q.heights[0]:
+		q.heights[0] = x
+		k = 0
+	case x >= q.heights[4]:
+		q.heights[4] = x
+		k = 3
+	default:
+		for k = 0; x >= q.heights[k+1]; k++ {
+		}
+	}
+	for i := k + 1; i < 5; i++ {
+		q.positions[i]++
+	}
+	for i := range q.desired {
+		q.desired[i] += q.increment[i]
+	}
+	for i := 1; i <= 3; i++ {
+		d := q.desired[i] - q.positions[i]
+		if (d >= 1 && q.positions[i+1]-q.positions[i] > 1) || (d <= -1 && q.positions[i-1]-q.positions[i] < -1) {
+			sign := math.Copysign(1, d)
+			height := q.parabolic(i, sign)
+			if !(q.heights[i-1] < height && height < q.heights[i+1]) {
+				height = q.linear(i, sign)
+			}
+			q.heights[i] = height
+			q.positions[i] += sign
+		}
+	}
+}
+
+func (q *P2Quantile) parabolic(i int, d float64) float64 {
+	n, h := q.positions, q.heights
+	return h[i] + d/(n[i+1]-n[i-1])*((n[i]-n[i-1]+d)*(h[i+1]-h[i])/(n[i+1]-n[i])+(n[i+1]-n[i]-d)*(h[i]-h[i-1])/(n[i]-n[i-1]))
+}
+
+func (q *P2Quantile) linear(i int, d float64) float64 {
+	j := i + int(d)
+	return q.heights[i] + d*(q.heights[j]-q.heights[i])/(q.positions[j]-q.positions[i])
+}
+
+func (q *P2Quantile) Count() uint {
+	return q.count
+}
+
+func (q *P2Quantile) Value() float64 {
+	if q.count >= 5 {
+		return q.heights[2]
+	}
+	if q.count == 0 {
+		return math.NaN()
+	}
+	samples := slices.Clone(q.heights[:q.count])
+	slices.Sort(samples)
+	return samples[int(math.Round(q.p*float64(q.count-1)))]
+}
+
+// Neighbors returns the heights of the markers on either side of the estimate, which track the p/2 and (1+p)/2
+// quantiles. They always enclose Value, but they are not an error bound for it.
+func (q *P2Quantile) Neighbors() (float64, float64) {
+	if q.count < 5 {
+		v := q.Value()
+		return v, v
+	}
+	return q.heights[1], q.heights[3]
+}
+
+func Quantile[T numeric](it iter.Seq[T], p float64) (float64, bool) {
+	q := NewP2Quantile(p)
+	for t := range it {
+		q.Push(float64(t))
+	}
+	return q.Value(), q.Count() > 0
+}
+
+func Median[T numeric](it iter.Seq[T]) (float64, bool) {
+	return Quantile(it, 0.5)
+}
@@ -0,0 +1,78 @@
+package custom_iter
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestLargestSmallestN(t *testing.T) {
+	tcs := []struct {
+		input        []int
+		k            uint
+		want1, want2 []int
+	}{
+		{[]int{5, 1, 9, 3, 7, 2}, 3, []int{9, 7, 5}, []int{1, 2, 3}},
+		{[]int{2, 1}, 5, []int{2, 1}, []int{1, 2}},
+		{[]int{2, 1}, 0, nil, nil},
+	}
+	for _, tc := range tcs {
+		t.Run(fmt.Sprintf("largest smallest %v %v", tc.input, tc.k), func(t *testing.T) {
+			got1, got2 := LargestN(slices.Values(tc.input), tc.k), SmallestN(slices.Values(tc.input), tc.k)
+			if !reflect.DeepEqual(got1, tc.want1) || !reflect.DeepEqual(got2, tc.want2) {
+				t.Errorf("got %v %v want %v %v\n", got1, got2, tc.want1, tc.want2)
+			}
+		})
+	}
+}
+
+func TestTopKBy(t *testing.T) {
+	got := TopKBy(slices.Values([]string{"ccc", "a", "bb", "dddd"}), 2, func(a, b string) int {
+		return cmp.Compare(len(a), len(b))
+	})
+	if want := []string{"dddd", "ccc"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v want %v\n", got, want)
+	}
+}
+
+func TestMeanVariance(t *testing.T) {
+	input := []int{2, 4, 4, 4, 5, 5, 7, 9}
+	mean, ok := Mean(slices.Values(input))
+	if mean != 5 || !ok {
+		t.Errorf("got %v %v want %v %v\n", mean, ok, 5, true)
+	}
+	variance, ok := Variance(slices.Values(input))
+	if variance != 4 || !ok {
+		t.Errorf("got %v %v want %v %v\n", variance, ok, 4, true)
+	}
+	if _, ok := Mean(slices.Values([]float64{})); ok {
+		t.Errorf("got %v want %v\n", ok, false)
+	}
+}
+
+func TestQuantile(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	input := r.Perm(100_001)
+	for _, p := range []float64{0.5, 0.9, 0.99} {
+		t.Run(fmt.Sprintf("quantile %v", p), func(t *testing.T) {
+			q := NewP2Quantile(p)
+			for _, x := range input {
+				q.Push(float64(x))
+			}
+			want := p * 100_000
+			if got := q.Value(); math.Abs(got-want) > 1000 {
+				t.Errorf("got %v want %v\n", got, want)
+			}
+			if lo, hi := q.Neighbors(); !(lo <= q.Value() && q.Value() <= hi) {
+				t.Errorf("got %v %v want neighbors around %v\n", lo, hi, q.Value())
+			}
+		})
+	}
+	if got, ok := Median(slices.Values([]int{3, 1, 2})); got != 2 || !ok {
+		t.Errorf("got %v %v want %v %v\n", got, ok, 2, true)
+	}
+}
@@ -0,0 +1,206 @@
+package custom_iter
+
+import (
+	"iter"
+	"runtime"
+	"sync"
+)
+
+type teeState[T any] struct {
+	source      iter.Seq[T]
+	next        func() (T, bool)
+	stop        func()
+	bufSize     uint
+	queue       []T
+	base        uint
+	offsets     []uint
+	active      []bool
+	activeCount int
+	exhausted   bool
+}
+
+// Tee splits it into n sequences fed by a single pass over it.
+// Elements are kept in a shared buffer until the slowest consumer has read them,
+// and Tee panics when consumers drift more than bufSize elements apart. A bufSize of 0 lets the buffer grow without bound.
+// A consumer that stops early is detached and the others continue; the source is stopped once every consumer is done.
+// A sequence that is never ranged over keeps the source suspended until every returned sequence is garbage collected.
+// The returned sequences must be consumed on one goroutine, each of them only once. Use TeeConcurrent otherwise.
+func Tee[T any](it iter.Seq[T], n, bufSize uint) []iter.Seq[T] {
+	s := &teeState[T]{
+		source:      it,
+		bufSize:     bufSize,
+		offsets:     make([]uint, n),
+		active:      make([]bool, n),
+		activeCount: int(n),
+	}
+	seqs := make([]iter.Seq[T], n)
+	for i := range seqs {
+		s.active[i] = true
+		seqs[i] = func(yield func(T) bool) {
+			if !s.active[i] {
+				return
+			}
+			defer s.leave(i)
+			for {
+				t, ok := s.get(i)
+				if !ok || !yield(t) {
+					return
+				}
+			}
+		}
+	}
+	return seqs
+}
+
+func (s *teeState[T]) get(i int) (T, bool) {
+	index := s.offsets[i] - s.base
+	if index == uint(len(s.queue)) {
+		var zero T
+		if s.exhausted {
+			return zero, false
+		}
+		if s.next == nil {
+			s.pull()
+		}
+		t, ok := s.next()
+		if !ok {
+			s.exhausted = true
+			return zero, false
+		}
+		if s.bufSize > 0 && uint(len(s.queue)) >= s.bufSize {
+			panic("Tee buffer overflow: consumers drifted too far apart")
+		}
+		s.queue = append(s.queue, t)
+	}
+	t := s.queue[index]
+	s.offsets[i]++
+	s.trim()
+	return t, true
+}
+
+// pull starts the source on first use. Its coroutine holds no reference to s, so once every sequence of the Tee is
+// unreachable, the cleanup stops it.
+func (s *teeState[T]) pull() {
+	s.next, s.stop = iter.Pull(s.source)
+	s.source = nil
+	runtime.AddCleanup(s, func(stop func()) { stop() }, s.stop)
+}
+
+func (s *teeState[T]) trim() {
+	lowest := s.base + uint(len(s.queue))
+	for i, offset := range s.offsets {
+		if s.active[i] {
+			lowest = min(lowest, offset)
+		}
+	}
+	var zero T
+	for j := range lowest - s.base {
+		s.queue[j] = zero
+	}
+	s.queue = s.queue[lowest-s.base:]
+	s.base = lowest
+}
+
+func (s *teeState[T]) leave(i int) {
+	s.active[i] = false
+	s.activeCount--
+	if s.activeCount == 0 {
+		if s.stop != nil {
+			s.stop()
+		}
+		s.queue = nil
+		return
+	}
+	s.trim()
+}
+
+// TeeConcurrent drives it on its own goroutine and hands every element to n consumers running concurrently.
+// Each consumer has a channel of bufSize elements, and the producer blocks while any attached consumer is full.
+// A consumer that stops early is detached and the others continue; the source is stopped once every consumer is done.
+// Every returned sequence has to be ranged over, otherwise the producer eventually blocks on it forever.
+// A panic in it is rethrown by every consumer that reaches the end of its sequence.
+func TeeConcurrent[T any](it iter.Seq[T], n, bufSize uint) []iter.Seq[T] {
+	chans := make([]chan T, n)
+	dones := make([]chan struct{}, n)
+	for i := range chans {
+		chans[i] = make(chan T, bufSize)
+		dones[i] = make(chan struct{})
+	}
+	var start sync.Once
+	var panicked any
+	produce := func() {
+		defer func() {
+			panicked = recover()
+			for _, ch := range chans {
+				close(ch)
+			}
+		}()
+		attached := make([]bool, n)
+		for i := range attached {
+			attached[i] = true
+		}
+		count := n
+		for t := range it {
+			for i, ch := range chans {
+				if !attached[i] {
+					continue
+				}
+				select {
+				case ch <- t:
+				case <-dones[i]:
+					attached[i] = false
+					count--
+				}
+			}
+			if count == 0 {
+				return
+			}
+		}
+	}
+	seqs := make([]iter.Seq[T], n)
+	for i := range seqs {
+		var leave sync.Once
+		seqs[i] = func(yield func(T) bool) {
+			start.Do(func() {
+				go produce()
+			})
+			defer leave.Do(func() {
+				close(dones[i])
+			})
+			for t := range chans[i] {
+				if !yield(t) {
+					return
+				}
+			}
+			if panicked != nil {
+				panic(panicked)
+			}
+		}
+	}
+	return seqs
+}
+
+type unzipPair[T, O any] struct {
+	t T
+	o O
+}
+
+// UnzipSinglePass is Unzip that reads it only once, buffering through an unbounded Tee.
+// The two halves can be consumed in any order; whatever one half is ahead of the other stays in memory,
+// so ranging the first half to the end buffers every element of the second.
+func UnzipSinglePass[T, O any](it iter.Seq2[T, O]) (iter.Seq[T], iter.Seq[O]) {
+	pairs := Tee(func(yield func(unzipPair[T, O]) bool) {
+		for t, o := range it {
+			if !yield(unzipPair[T, O]{t, o}) {
+				return
+			}
+		}
+	}, 2, 0)
+	ts := Map(pairs[0], func(pair unzipPair[T, O]) T {
+		return pair.t
+	})
+	os := Map(pairs[1], func(pair unzipPair[T, O]) O {
+		return pair.o
+	})
+	return ts, os
+}
@@ -0,0 +1,151 @@
+package custom_iter
+
+import (
+	"reflect"
+	"runtime"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+func singleShot(input []int, passes *int) func(yield func(int) bool) {
+	return func(yield func(int) bool) {
+		*passes++
+		for _, i := range input {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+}
+
+func TestTee(t *testing.T) {
+	passes := 0
+	seqs := Tee(singleShot([]int{1, 2, 3, 4}, &passes), 3, 4)
+	var got1, got2 []int
+	for a, b := range Zip(seqs[0], seqs[1]) {
+		got1 = append(got1, a)
+		got2 = append(got2, b)
+	}
+	got3 := slices.Collect(seqs[2])
+	want := []int{1, 2, 3, 4}
+	if !reflect.DeepEqual(got1, want) || !reflect.DeepEqual(got2, want) || !reflect.DeepEqual(got3, want) || passes != 1 {
+		t.Errorf("got %v %v %v %v want %v %v\n", got1, got2, got3, passes, want, 1)
+	}
+}
+
+func TestTeeEarlyStop(t *testing.T) {
+	passes := 0
+	seqs := Tee(singleShot([]int{1, 2, 3, 4, 5}, &passes), 2, 2)
+	got1 := slices.Collect(Take(seqs[0], 1))
+	got2 := slices.Collect(seqs[1])
+	if !reflect.DeepEqual(got1, []int{1}) || !reflect.DeepEqual(got2, []int{1, 2, 3, 4, 5}) || passes != 1 {
+		t.Errorf("got %v %v %v want %v %v %v\n", got1, got2, passes, []int{1}, []int{1, 2, 3, 4, 5}, 1)
+	}
+}
+
+func TestTeeOverflow(t *testing.T) {
+	defer func() {
+		if p := recover(); p == nil {
+			t.Errorf("got %v want panic\n", p)
+		}
+	}()
+	seqs := Tee(slices.Values([]int{1, 2, 3}), 2, 2)
+	defer Count(seqs[1])
+	Count(seqs[0])
+}
+
+func TestTeeReleasesUnrangedBranch(t *testing.T) {
+	released := make(chan struct{})
+	endless := func(yield func(int) bool) {
+		defer close(released)
+		for i := 0; ; i++ {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+	func() {
+		seqs := Tee(endless, 2, 4)
+		if got := slices.Collect(Take(seqs[0], 2)); !reflect.DeepEqual(got, []int{0, 1}) {
+			t.Errorf("got %v want %v\n", got, []int{0, 1})
+		}
+	}()
+	deadline := time.After(time.Second)
+	for {
+		runtime.GC()
+		select {
+		case <-released:
+			return
+		case <-deadline:
+			t.Fatalf("source of an abandoned Tee was never stopped\n")
+		case <-time.After(time.Millisecond):
+		}
+	}
+}
+
+func TestTeeConcurrent(t *testing.T) {
+	passes := 0
+	seqs := TeeConcurrent(singleShot([]int{1, 2, 3, 4, 5}, &passes), 3, 1)
+	got := make([][]int, 3)
+	var wg sync.WaitGroup
+	for i, seq := range seqs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if i == 2 {
+				got[i] = slices.Collect(Take(seq, 2))
+				return
+			}
+			got[i] = slices.Collect(seq)
+		}()
+	}
+	wg.Wait()
+	want := [][]int{{1, 2, 3, 4, 5}, {1, 2, 3, 4, 5}, {1, 2}}
+	if !reflect.DeepEqual(got, want) || passes != 1 {
+		t.Errorf("got %v %v want %v %v\n", got, passes, want, 1)
+	}
+}
+
+func TestTeeConcurrentPanic(t *testing.T) {
+	seqs := TeeConcurrent(func(yield func(int) bool) {
+		yield(1)
+		panic("oops")
+	}, 2, 1)
+	got := make([]any, 2)
+	var wg sync.WaitGroup
+	for i, seq := range seqs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { got[i] = recover() }()
+			Count(seq)
+		}()
+	}
+	wg.Wait()
+	if !reflect.DeepEqual(got, []any{"oops", "oops"}) {
+		t.Errorf("got %v want %v\n", got, []any{"oops", "oops"})
+	}
+}
+
+func TestUnzipSinglePass(t *testing.T) {
+	passes := 0
+	pairs := Enumerate(singleShot([]int{5, 6, 7}, &passes))
+	indices, values := UnzipSinglePass(pairs)
+	got1, got2 := slices.Collect(indices), slices.Collect(values)
+	if !reflect.DeepEqual(got1, []uint{0, 1, 2}) || !reflect.DeepEqual(got2, []int{5, 6, 7}) || passes != 1 {
+		t.Errorf("got %v %v %v want %v %v %v\n", got1, got2, passes, []uint{0, 1, 2}, []int{5, 6, 7}, 1)
+	}
+}
+
+func TestUnzipSinglePassSequential(t *testing.T) {
+	passes := 0
+	input := slices.Collect(Range(0, 1000, 1).All())
+	indices, values := UnzipSinglePass(Enumerate(singleShot(input, &passes)))
+	got1 := slices.Collect(indices)
+	got2 := slices.Collect(values)
+	if len(got1) != len(input) || !reflect.DeepEqual(got2, input) || passes != 1 {
+		t.Errorf("got %v %v %v want %v %v %v\n", len(got1), len(got2), passes, len(input), len(input), 1)
+	}
+}
@@ -0,0 +1,259 @@
+package custom_iter
+
+import (
+	"errors"
+	"iter"
+	"time"
+)
+
+var ErrTimeout = errors.New("timed out waiting for the next element")
+
+// timedSource runs it on its own goroutine for the time-based iterators.
+// The producer is released only after the consumer accepted an element, so that a producer driving a ManualClock
+// observes every element handled before it moves time.
+type timedSource[T any] struct {
+	ch       chan T
+	accepted chan struct{}
+	quit     chan struct{}
+	finished chan struct{}
+	panicked any
+}
+
+func startTimedSource[T any](it iter.Seq[T]) *timedSource[T] {
+	s := &timedSource[T]{
+		ch:       make(chan T),
+		accepted: make(chan struct{}),
+		quit:     make(chan struct{}),
+		finished: make(chan struct{}),
+	}
+	go func() {
+		defer close(s.finished)
+		defer close(s.ch)
+		defer func() {
+			s.panicked = recover()
+		}()
+		for t := range it {
+			select {
+			case s.ch <- t:
+			case <-s.quit:
+				return
+			}
+			select {
+			case <-s.accepted:
+			case <-s.quit:
+				return
+			}
+		}
+	}()
+	return s
+}
+
+// accept releases the producer after an element was received from ch.
+func (s *timedSource[T]) accept() {
+	s.accepted <- struct{}{}
+}
+
+// stop releases the producer, waits for it to return and rethrows its panic.
+func (s *timedSource[T]) stop() {
+	close(s.quit)
+	<-s.finished
+	if s.panicked != nil {
+		panic(s.panicked)
+	}
+}
+
+// abandon releases the producer without waiting for it, for when it may be blocked for good inside it.
+func (s *timedSource[T]) abandon() {
+	close(s.quit)
+}
+
+// BatchTimed groups elements into batches of up to maxSize, yielding a batch early once maxWait passed since its
+// first element. A maxWait of 0 batches by size only. it runs on its own goroutine.
+func BatchTimed[T any](it iter.Seq[T], maxSize uint, maxWait time.Duration, clock Clock) iter.Seq[[]T] {
+	if maxSize == 0 {
+		panic("zero size for BatchTimed")
+	}
+	return func(yield func([]T) bool) {
+		src := startTimedSource(it)
+		defer src.stop()
+		var batch []T
+		var timer Timer
+		var timeout <-chan time.Time
+		flush := func() bool {
+			if timer != nil {
+				timer.Stop()
+				timer, timeout = nil, nil
+			}
+			b := batch
+			batch = nil
+			return yield(b)
+		}
+		for {
+			select {
+			case t, ok := <-src.ch:
+				if !ok {
+					if len(batch) > 0 {
+						flush()
+					}
+					return
+				}
+				if len(batch) == 0 {
+					batch = make([]T, 0, maxSize)
+					if maxWait > 0 {
+						timer = clock.NewTimer(maxWait)
+						timeout = timer.C()
+					}
+				}
+				batch = append(batch, t)
+				full := uint(len(batch)) == maxSize
+				if full && timer != nil {
+					timer.Stop()
+					timer, timeout = nil, nil
+				}
+				src.accept()
+				if full && !flush() {
+					return
+				}
+			case <-timeout:
+				timer, timeout = nil, nil
+				if !flush() {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Throttle delays elements so that at most one is yielded per interval. Nothing is dropped.
+func Throttle[T any](it iter.Seq[T], interval time.Duration, clock Clock) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		var last time.Time
+		first := true
+		for t := range it {
+			if !first {
+				if wait := last.Add(interval).Sub(clock.Now()); wait > 0 {
+					<-clock.NewTimer(wait).C()
+				}
+			}
+			first = false
+			last = clock.Now()
+			if !yield(t) {
+				return
+			}
+		}
+	}
+}
+
+// Debounce yields an element only once d passed without a newer one. The last element is always yielded.
+// it runs on its own goroutine.
+func Debounce[T any](it iter.Seq[T], d time.Duration, clock Clock) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		src := startTimedSource(it)
+		defer src.stop()
+		var pending T
+		hasPending := false
+		var timer Timer
+		var timeout <-chan time.Time
+		defer func() {
+			if timer != nil {
+				timer.Stop()
+			}
+		}()
+		for {
+			select {
+			case t, ok := <-src.ch:
+				if !ok {
+					if hasPending {
+						yield(pending)
+					}
+					return
+				}
+				pending, hasPending = t, true
+				if timer != nil {
+					timer.Stop()
+				}
+				timer = clock.NewTimer(d)
+				timeout = timer.C()
+				src.accept()
+			case <-timeout:
+				timer, timeout = nil, nil
+				hasPending = false
+				if !yield(pending) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Sample yields the latest element once every period, if there was a new one during it.
+// An element arriving after the last full period is not yielded. it runs on its own goroutine.
+func Sample[T any](it iter.Seq[T], period time.Duration, clock Clock) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		ticker := clock.NewTicker(period)
+		defer ticker.Stop()
+		src := startTimedSource(it)
+		defer src.stop()
+		var latest T
+		hasLatest := false
+		for {
+			select {
+			case t, ok := <-src.ch:
+				if !ok {
+					return
+				}
+				latest, hasLatest = t, true
+				src.accept()
+			case <-ticker.C():
+				if hasLatest {
+					hasLatest = false
+					if !yield(latest) {
+						return
+					}
+				}
+			}
+		}
+	}
+}
+
+// TimeoutEach yields the elements of it, failing with ErrTimeout once the next element takes longer than d to
+// arrive after the loop body finished with the previous one, so a slow consumer never causes a timeout.
+// it runs on its own goroutine, which is abandoned on timeout and exits whenever it next yields or ends.
+func TimeoutEach[T any](it iter.Seq[T], d time.Duration, clock Clock) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		src := startTimedSource(it)
+		timedOut := false
+		defer func() {
+			if timedOut {
+				src.abandon()
+			} else {
+				src.stop()
+			}
+		}()
+		timer := clock.NewTimer(d)
+		defer func() {
+			timer.Stop()
+		}()
+		for {
+			select {
+			case t, ok := <-src.ch:
+				if !ok {
+					return
+				}
+				timer.Stop()
+				if !yield(t, nil) {
+					return
+				}
+				// The producer is released only once the next deadline is set, so that it cannot move a
+				// ManualClock past it before it exists.
+				timer = clock.NewTimer(d)
+				src.accept()
+			case <-timer.C():
+				timedOut = true
+				var zero T
+				yield(zero, ErrTimeout)
+				return
+			}
+		}
+	}
+}
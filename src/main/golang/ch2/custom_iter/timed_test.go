@@ -0,0 +1,141 @@
+package custom_iter
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+)
+
+// script yields values, calling step between them, e.g. to advance a ManualClock.
+func script(steps ...func(yield func(int) bool) bool) func(yield func(int) bool) {
+	return func(yield func(int) bool) {
+		for _, step := range steps {
+			if !step(yield) {
+				return
+			}
+		}
+	}
+}
+
+func emit(values ...int) func(yield func(int) bool) bool {
+	return func(yield func(int) bool) bool {
+		for _, v := range values {
+			if !yield(v) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+func advance(clock *ManualClock, d time.Duration) func(yield func(int) bool) bool {
+	return func(func(int) bool) bool {
+		clock.Advance(d)
+		return true
+	}
+}
+
+func TestBatchTimed(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 0))
+	ms := time.Millisecond
+	it := script(emit(1, 2, 3), emit(4, 5), advance(clock, 100*ms), emit(6))
+	got := slices.Collect(BatchTimed(it, 3, 100*ms, clock))
+	if want := [][]int{{1, 2, 3}, {4, 5}, {6}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v want %v\n", got, want)
+	}
+
+	defer func() {
+		if p := recover(); p != "boom" {
+			t.Errorf("got panic %v want %v\n", p, "boom")
+		}
+	}()
+	for range BatchTimed(func(func(int) bool) { panic("boom") }, 2, 0, clock) {
+	}
+	t.Errorf("panic was not rethrown\n")
+}
+
+func TestThrottle(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 0))
+	go func() {
+		for range 2 {
+			clock.BlockUntilTimers(1)
+			clock.Advance(10 * time.Millisecond)
+		}
+	}()
+	var got []time.Duration
+	for range Throttle(slices.Values([]int{0, 1, 2}), 10*time.Millisecond, clock) {
+		got = append(got, clock.Now().Sub(time.Unix(0, 0)))
+	}
+	if want := []time.Duration{0, 10 * time.Millisecond, 20 * time.Millisecond}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v want %v\n", got, want)
+	}
+}
+
+func TestDebounce(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 0))
+	ms := time.Millisecond
+	it := script(emit(1), advance(clock, 5*ms), emit(2), advance(clock, 10*ms), emit(3, 4))
+	if got := slices.Collect(Debounce(it, 10*ms, clock)); !reflect.DeepEqual(got, []int{2, 4}) {
+		t.Errorf("got %v want %v\n", got, []int{2, 4})
+	}
+}
+
+func TestSample(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 0))
+	ms := time.Millisecond
+	it := script(emit(1, 2), advance(clock, 10*ms), advance(clock, 10*ms), emit(3), advance(clock, 10*ms), emit(4))
+	if got := slices.Collect(Sample(it, 10*ms, clock)); !reflect.DeepEqual(got, []int{2, 3}) {
+		t.Errorf("got %v want %v\n", got, []int{2, 3})
+	}
+}
+
+func TestTimeoutEach(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 0))
+	ms := time.Millisecond
+	it := script(emit(1), advance(clock, 5*ms), emit(2), advance(clock, 10*ms), emit(3))
+	var values []int
+	var errs []error
+	for v, err := range TimeoutEach(it, 10*ms, clock) {
+		values, errs = append(values, v), append(errs, err)
+	}
+	if !reflect.DeepEqual(values, []int{1, 2, 0}) || !reflect.DeepEqual(errs, []error{nil, nil, ErrTimeout}) {
+		t.Errorf("got %v %v want %v %v\n", values, errs, []int{1, 2, 0}, []error{nil, nil, ErrTimeout})
+	}
+
+	hang := make(chan int)
+	defer close(hang)
+	for _, err := range TimeoutEach(FromChan(hang), 5*ms, SystemClock) {
+		if !errors.Is(err, ErrTimeout) {
+			t.Errorf("got %v want %v\n", err, ErrTimeout)
+		}
+	}
+}
+
+func TestTimeoutEachSlowConsumer(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 0))
+	ms := time.Millisecond
+	var values []int
+	for v, err := range TimeoutEach(slices.Values([]int{1, 2, 3}), 10*ms, clock) {
+		if err != nil {
+			t.Errorf("got %v want %v\n", err, nil)
+			return
+		}
+		values = append(values, v)
+		advanced := make(chan struct{})
+		go func() {
+			clock.Advance(20 * ms)
+			close(advanced)
+		}()
+		select {
+		case <-advanced:
+		case <-time.After(time.Second):
+			t.Errorf("the deadline of the next element ran during the loop body\n")
+			return
+		}
+	}
+	if !reflect.DeepEqual(values, []int{1, 2, 3}) {
+		t.Errorf("got %v want %v\n", values, []int{1, 2, 3})
+	}
+}
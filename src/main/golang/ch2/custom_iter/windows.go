@@ -0,0 +1,149 @@
+package custom_iter
+
+import (
+	"iter"
+	"slices"
+)
+
+// The *Buf variants reuse one buffer across iterations, so each yielded slice is only valid until the next one.
+// Use the copying variants when the slices outlive the loop body.
+
+func Windows[T any](it iter.Seq[T], n uint) iter.Seq[[]T] {
+	return cloned(WindowsBuf(it, n))
+}
+
+func WindowsBuf[T any](it iter.Seq[T], n uint) iter.Seq[[]T] {
+	if n == 0 {
+		panic("Windows size cannot be zero")
+	}
+	return func(yield func([]T) bool) {
+		buf := make([]T, 0, 2*n)
+		for t := range it {
+			if uint(len(buf)) == 2*n {
+				buf = append(buf[:0], buf[n+1:]...)
+			}
+			buf = append(buf, t)
+			if uint(len(buf)) >= n {
+				if !yield(buf[uint(len(buf))-n : len(buf) : len(buf)]) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func Chunks[T any](it iter.Seq[T], n uint) iter.Seq[[]T] {
+	return cloned(ChunksBuf(it, n))
+}
+
+func ChunksBuf[T any](it iter.Seq[T], n uint) iter.Seq[[]T] {
+	if n == 0 {
+		panic("Chunks size cannot be zero")
+	}
+	return func(yield func([]T) bool) {
+		buf := make([]T, 0, n)
+		for t := range it {
+			buf = append(buf, t)
+			if uint(len(buf)) == n {
+				if !yield(buf) {
+					return
+				}
+				buf = buf[:0]
+			}
+		}
+		if len(buf) > 0 {
+			yield(buf)
+		}
+	}
+}
+
+// ChunksExact yields only chunks of exactly n elements.
+// The leftover elements are returned by remainder once the sequence has been fully consumed.
+func ChunksExact[T any](it iter.Seq[T], n uint) (chunks iter.Seq[[]T], remainder func() []T) {
+	chunksBuf, remainderBuf := ChunksExactBuf(it, n)
+	return cloned(chunksBuf), func() []T {
+		return slices.Clone(remainderBuf())
+	}
+}
+
+func ChunksExactBuf[T any](it iter.Seq[T], n uint) (chunks iter.Seq[[]T], remainder func() []T) {
+	if n == 0 {
+		panic("ChunksExact size cannot be zero")
+	}
+	var rest []T
+	chunks = func(yield func([]T) bool) {
+		rest = nil
+		buf := make([]T, 0, n)
+		for t := range it {
+			buf = append(buf, t)
+			if uint(len(buf)) == n {
+				if !yield(buf) {
+					return
+				}
+				buf = buf[:0]
+			}
+		}
+		rest = buf
+	}
+	remainder = func() []T {
+		return rest
+	}
+	return
+}
+
+// ChunkBy groups runs of consecutive elements with equal keys.
+func ChunkBy[T any, K comparable](it iter.Seq[T], keyFn func(T) K) iter.Seq2[K, []T] {
+	return func(yield func(K, []T) bool) {
+		for k, chunk := range ChunkByBuf(it, keyFn) {
+			if !yield(k, slices.Clone(chunk)) {
+				return
+			}
+		}
+	}
+}
+
+func ChunkByBuf[T any, K comparable](it iter.Seq[T], keyFn func(T) K) iter.Seq2[K, []T] {
+	return func(yield func(K, []T) bool) {
+		var buf []T
+		var key K
+		for t := range it {
+			k := keyFn(t)
+			if len(buf) > 0 && k != key {
+				if !yield(key, buf) {
+					return
+				}
+				buf = buf[:0]
+			}
+			key = k
+			buf = append(buf, t)
+		}
+		if len(buf) > 0 {
+			yield(key, buf)
+		}
+	}
+}
+
+func Pairwise[T any](it iter.Seq[T]) iter.Seq2[T, T] {
+	return func(yield func(T, T) bool) {
+		var prev T
+		notFirst := false
+		for t := range it {
+			if notFirst {
+				if !yield(prev, t) {
+					return
+				}
+			}
+			prev, notFirst = t, true
+		}
+	}
+}
+
+func cloned[T any](it iter.Seq[[]T]) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		for s := range it {
+			if !yield(slices.Clone(s)) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package custom_iter
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestWindows(t *testing.T) {
+	tcs := []struct {
+		input []int
+		n     uint
+		want  [][]int
+	}{
+		{[]int{1, 2, 3, 4, 5}, 3, [][]int{{1, 2, 3}, {2, 3, 4}, {3, 4, 5}}},
+		{[]int{1, 2, 3, 4, 5, 6, 7}, 2, [][]int{{1, 2}, {2, 3}, {3, 4}, {4, 5}, {5, 6}, {6, 7}}},
+		{[]int{1, 2}, 3, nil},
+		{[]int{1, 2, 3}, 1, [][]int{{1}, {2}, {3}}},
+	}
+	for _, tc := range tcs {
+		t.Run(fmt.Sprintf("windows %v %v", tc.input, tc.n), func(t *testing.T) {
+			got := slices.Collect(Windows(slices.Values(tc.input), tc.n))
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v want %v\n", got, tc.want)
+			}
+			var sums []int
+			for w := range WindowsBuf(slices.Values(tc.input), tc.n) {
+				sums = append(sums, Sum(slices.Values(w)))
+			}
+			wantSums := slices.Collect(Map(slices.Values(tc.want), func(w []int) int { return Sum(slices.Values(w)) }))
+			if !reflect.DeepEqual(sums, wantSums) {
+				t.Errorf("got %v want %v\n", sums, wantSums)
+			}
+		})
+	}
+}
+
+func TestChunks(t *testing.T) {
+	tcs := []struct {
+		input []int
+		n     uint
+		want  [][]int
+	}{
+		{[]int{1, 2, 3, 4, 5}, 2, [][]int{{1, 2}, {3, 4}, {5}}},
+		{[]int{1, 2, 3, 4}, 2, [][]int{{1, 2}, {3, 4}}},
+		{nil, 2, nil},
+	}
+	for _, tc := range tcs {
+		t.Run(fmt.Sprintf("chunks %v %v", tc.input, tc.n), func(t *testing.T) {
+			got := slices.Collect(Chunks(slices.Values(tc.input), tc.n))
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v want %v\n", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestChunksExact(t *testing.T) {
+	chunks, remainder := ChunksExact(slices.Values([]int{1, 2, 3, 4, 5}), 2)
+	got := slices.Collect(chunks)
+	if !reflect.DeepEqual(got, [][]int{{1, 2}, {3, 4}}) || !reflect.DeepEqual(remainder(), []int{5}) {
+		t.Errorf("got %v %v want %v %v\n", got, remainder(), [][]int{{1, 2}, {3, 4}}, []int{5})
+	}
+}
+
+func TestChunkBy(t *testing.T) {
+	var keys []bool
+	var got [][]int
+	for k, chunk := range ChunkBy(slices.Values([]int{1, 3, 2, 4, 6, 5}), func(i int) bool { return i%2 == 0 }) {
+		keys = append(keys, k)
+		got = append(got, chunk)
+	}
+	want := [][]int{{1, 3}, {2, 4, 6}, {5}}
+	if !reflect.DeepEqual(got, want) || !reflect.DeepEqual(keys, []bool{false, true, false}) {
+		t.Errorf("got %v %v want %v %v\n", got, keys, want, []bool{false, true, false})
+	}
+}
+
+func TestPairwise(t *testing.T) {
+	var got [][2]int
+	for a, b := range Pairwise(slices.Values([]int{1, 2, 3})) {
+		got = append(got, [2]int{a, b})
+	}
+	want := [][2]int{{1, 2}, {2, 3}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v want %v\n", got, want)
+	}
+}
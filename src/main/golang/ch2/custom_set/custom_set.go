@@ -1,11 +1,16 @@
 package custom_set
 
-import "iter"
+import (
+	"examples/ch2/combinatorics"
+	"iter"
+	"slices"
+)
 
 type Set[T comparable] map[T]struct{}
 
 func New[T comparable]() *Set[T] {
-	return new(Set[T])
+	set := make(Set[T])
+	return &set
 }
 
 func (set *Set[T]) Iter() iter.Seq[T] {
@@ -153,3 +158,13 @@ func (set *Set[T]) IsDisjointWith(other *Set[T]) bool {
 	}
 	return true
 }
+
+func (set *Set[T]) Subsets() iter.Seq[*Set[T]] {
+	return func(yield func(*Set[T]) bool) {
+		for subset := range combinatorics.Powerset(slices.Collect(set.Iter())) {
+			if !yield(Collect(slices.Values(subset))) {
+				return
+			}
+		}
+	}
+}
@@ -1,9 +1,26 @@
 package custom_set
 
-import "testing"
+import (
+	"slices"
+	"testing"
+)
 
 func TestCustomSet(t *testing.T) {
 	if s := New[string](); s == nil {
 		t.Errorf("New[string]() returned nil %+v", s)
 	}
 }
+
+func TestSubsets(t *testing.T) {
+	set := Collect(slices.Values([]int{1, 2, 3}))
+	count := 0
+	for subset := range set.Subsets() {
+		if !subset.IsSubsetOf(set) {
+			t.Errorf("%v is not a subset of %v", *subset, *set)
+		}
+		count++
+	}
+	if count != 8 {
+		t.Errorf("got %d subsets, want %d", count, 8)
+	}
+}
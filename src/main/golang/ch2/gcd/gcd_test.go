@@ -1,6 +1,9 @@
 package gcd
 
-import "testing"
+import (
+	"examples/ch2/combinatorics"
+	"testing"
+)
 
 func TestGCD(t *testing.T) {
 	for _, tc := range testcases {
@@ -13,6 +16,20 @@ func TestGCD(t *testing.T) {
 	}
 }
 
+func TestGCDExhaustive(t *testing.T) {
+	pool := []uint8{0, 1, 2, 3, 4, 6, 9, 12, 17, 255}
+	for pair := range combinatorics.CartesianProduct(pool, pool) {
+		a, b := pair[0], pair[1]
+		got := GCD(a, b)
+		if got != GCD(b, a) {
+			t.Errorf("GCD(%v, %v) = %d is not commutative", a, b, got)
+		}
+		if got != 0 && (a%got != 0 || b%got != 0) {
+			t.Errorf("GCD(%v, %v) = %d does not divide both", a, b, got)
+		}
+	}
+}
+
 var testcases = []struct {
 	a, b, want uint64
 }{
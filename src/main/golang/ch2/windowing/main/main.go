@@ -0,0 +1,52 @@
+package main
+
+import (
+	"bufio"
+	"examples/ch2/windowing"
+	"fmt"
+	"iter"
+	"log"
+	"os"
+	"strings"
+	"time"
+)
+
+// Reads access log lines of the form "<RFC 3339 time> <method> <path> <status>" from stdin,
+// and prints the number of requests per path and minute.
+func main() {
+	cfg := windowing.Config{MaxOutOfOrder: 10 * time.Second, Late: windowing.SideOutputLate}
+	counts := windowing.Tumbling(accessLog(os.Stdin), time.Minute, cfg, uint(0), func(n uint, _ string) uint {
+		return n + 1
+	})
+	for result := range counts {
+		late := ""
+		if result.Late {
+			late = " (late)"
+		}
+		fmt.Printf("%s %s %d%s\n", result.Window.Start.Format(time.TimeOnly), result.Key, result.Value, late)
+	}
+}
+
+func accessLog(file *os.File) iter.Seq[windowing.Record[string, string]] {
+	return func(yield func(windowing.Record[string, string]) bool) {
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			fields := strings.Fields(scanner.Text())
+			if len(fields) != 4 {
+				log.Printf("malformed access log line: %q\n", scanner.Text())
+				continue
+			}
+			t, err := time.Parse(time.RFC3339, fields[0])
+			if err != nil {
+				log.Printf("malformed access log time: %+v\n", err)
+				continue
+			}
+			if !yield(windowing.Record[string, string]{Key: fields[2], Time: t, Value: fields[3]}) {
+				return
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			log.Printf("error reading access log: %+v\n", err)
+		}
+	}
+}
@@ -0,0 +1,333 @@
+// Package windowing aggregates streams of timestamped records over event-time windows, like Apache Flink or Beam.
+// Records may arrive out of order: the watermark trails the latest event time by Config.MaxOutOfOrder, and a window
+// is emitted once the watermark passes its end. Records arriving for a window the watermark already passed are late,
+// and handled by Config.Late. Every remaining window is emitted when the input ends.
+// Windows closing together are emitted in order of end, start and first appearance of their key.
+package windowing
+
+import (
+	"cmp"
+	"examples/ch2/custom_iter"
+	"fmt"
+	"iter"
+	"slices"
+	"time"
+)
+
+type Record[K comparable, V any] struct {
+	Key   K
+	Time  time.Time
+	Value V
+}
+
+// Window is the half-open interval [Start, End).
+type Window struct {
+	Start time.Time
+	End   time.Time
+}
+
+func (w Window) Contains(t time.Time) bool {
+	return !t.Before(w.Start) && t.Before(w.End)
+}
+
+type Result[K comparable, A any] struct {
+	Key    K
+	Window Window
+	Value  A
+	Count  uint
+	// Late marks a result including late records. Under UpdateLate it supersedes an earlier result of the window.
+	Late bool
+}
+
+type LatePolicy int
+
+const (
+	// DropLate discards late records.
+	DropLate LatePolicy = iota
+	// UpdateLate keeps windows for Config.AllowedLateness after they were emitted, and emits a window again,
+	// marked Late, for every late record added to it. Records later than that are discarded.
+	UpdateLate
+	// SideOutputLate emits every late record on its own, as a Late result of the window it belongs to.
+	SideOutputLate
+)
+
+func (p LatePolicy) String() string {
+	switch p {
+	case DropLate:
+		return "drop"
+	case UpdateLate:
+		return "update"
+	case SideOutputLate:
+		return "side-output"
+	}
+	return fmt.Sprintf("LatePolicy(%d)", int(p))
+}
+
+type Config struct {
+	MaxOutOfOrder   time.Duration
+	Late            LatePolicy
+	AllowedLateness time.Duration
+}
+
+// watermark tracks event time, and decides which windows are late or still retained.
+type watermark struct {
+	cfg   Config
+	time  time.Time
+	valid bool
+}
+
+// observe moves the watermark for a record at t, and reports whether it moved.
+func (w *watermark) observe(t time.Time) bool {
+	next := t.Add(-w.cfg.MaxOutOfOrder)
+	if w.valid && !next.After(w.time) {
+		return false
+	}
+	w.time, w.valid = next, true
+	return true
+}
+
+// passed reports whether the watermark reached end.
+func (w *watermark) passed(end time.Time) bool {
+	return w.valid && !end.After(w.time)
+}
+
+// retained reports whether a window ending at end may still be updated by late records.
+func (w *watermark) retained(end time.Time) bool {
+	return w.cfg.Late == UpdateLate && (!w.valid || end.Add(w.cfg.AllowedLateness).After(w.time))
+}
+
+// keyOrder numbers keys by first appearance, to emit simultaneous windows deterministically.
+type keyOrder[K comparable] map[K]uint64
+
+func (o keyOrder[K]) of(key K) uint64 {
+	seq, ok := o[key]
+	if !ok {
+		seq = uint64(len(o))
+		o[key] = seq
+	}
+	return seq
+}
+
+func compareEmission(a, b Window, seqA, seqB uint64) int {
+	if c := a.End.Compare(b.End); c != 0 {
+		return c
+	}
+	if c := a.Start.Compare(b.Start); c != 0 {
+		return c
+	}
+	return cmp.Compare(seqA, seqB)
+}
+
+// Tumbling aggregates each key over consecutive windows of size, aligned on the zero time.
+func Tumbling[K comparable, V, A any](
+	records iter.Seq[Record[K, V]], size time.Duration, cfg Config, init A, foldFn func(A, V) A,
+) iter.Seq[Result[K, A]] {
+	if size <= 0 {
+		panic("non-positive window size")
+	}
+	return fixed(records, cfg, init, foldFn, func(t time.Time, windows []Window) []Window {
+		start := t.Truncate(size)
+		return append(windows, Window{start, start.Add(size)})
+	})
+}
+
+// Hopping aggregates each key over windows of size starting every hop, so that a record belongs to size/hop windows.
+func Hopping[K comparable, V, A any](
+	records iter.Seq[Record[K, V]], size, hop time.Duration, cfg Config, init A, foldFn func(A, V) A,
+) iter.Seq[Result[K, A]] {
+	if size <= 0 || hop <= 0 {
+		panic("non-positive window size or hop")
+	}
+	return fixed(records, cfg, init, foldFn, func(t time.Time, windows []Window) []Window {
+		first := len(windows)
+		for start := t.Truncate(hop); start.Add(size).After(t); start = start.Add(-hop) {
+			windows = append(windows, Window{start, start.Add(size)})
+		}
+		slices.Reverse(windows[first:])
+		return windows
+	})
+}
+
+type fixedKey[K comparable] struct {
+	key   K
+	start int64
+}
+
+type fixedSlot[K comparable, A any] struct {
+	key    K
+	seq    uint64
+	window Window
+	acc    A
+	count  uint
+	fired  bool
+}
+
+func fixed[K comparable, V, A any](
+	records iter.Seq[Record[K, V]], cfg Config, init A, foldFn func(A, V) A,
+	assign func(t time.Time, windows []Window) []Window,
+) iter.Seq[Result[K, A]] {
+	return func(yield func(Result[K, A]) bool) {
+		wm := watermark{cfg: cfg}
+		order := make(keyOrder[K])
+		slots := make(map[fixedKey[K]]*fixedSlot[K, A])
+		var windows []Window
+		var due []*fixedSlot[K, A]
+
+		emit := func(final bool) bool {
+			due = due[:0]
+			for k, slot := range slots {
+				if slot.fired && !final && !wm.retained(slot.window.End) {
+					delete(slots, k)
+				} else if !slot.fired && (final || wm.passed(slot.window.End)) {
+					due = append(due, slot)
+				}
+			}
+			slices.SortFunc(due, func(a, b *fixedSlot[K, A]) int {
+				return compareEmission(a.window, b.window, a.seq, b.seq)
+			})
+			for _, slot := range due {
+				slot.fired = true
+				if !yield(Result[K, A]{Key: slot.key, Window: slot.window, Value: slot.acc, Count: slot.count}) {
+					return false
+				}
+			}
+			return true
+		}
+
+		for r := range records {
+			seq := order.of(r.Key)
+			windows = assign(r.Time, windows[:0])
+			for _, w := range windows {
+				k := fixedKey[K]{r.Key, w.Start.UnixNano()}
+				slot := slots[k]
+				late := wm.passed(w.End)
+				if late && !wm.retained(w.End) {
+					if cfg.Late == SideOutputLate {
+						if !yield(Result[K, A]{Key: r.Key, Window: w, Value: foldFn(init, r.Value), Count: 1, Late: true}) {
+							return
+						}
+					}
+					continue
+				}
+				if slot == nil {
+					slot = &fixedSlot[K, A]{key: r.Key, seq: seq, window: w, acc: init}
+					slots[k] = slot
+				}
+				slot.acc = foldFn(slot.acc, r.Value)
+				slot.count++
+				if late {
+					slot.fired = true
+					if !yield(Result[K, A]{Key: r.Key, Window: w, Value: slot.acc, Count: slot.count, Late: true}) {
+						return
+					}
+				}
+			}
+			if wm.observe(r.Time) && !emit(false) {
+				return
+			}
+		}
+		emit(true)
+	}
+}
+
+type session[K comparable, V any] struct {
+	key        K
+	seq        uint64
+	window     Window
+	values     []V
+	fired      bool
+	superseded bool
+}
+
+// Session aggregates each key over sessions: maximal runs of records less than gap apart.
+// A session is the window from its first record to gap after its last one. Values are buffered and only folded when
+// the session is emitted, since a record may merge two sessions.
+func Session[K comparable, V, A any](
+	records iter.Seq[Record[K, V]], gap time.Duration, cfg Config, init A, foldFn func(A, V) A,
+) iter.Seq[Result[K, A]] {
+	if gap <= 0 {
+		panic("non-positive session gap")
+	}
+	return func(yield func(Result[K, A]) bool) {
+		wm := watermark{cfg: cfg}
+		order := make(keyOrder[K])
+		sessions := make(map[K][]*session[K, V])
+		var due []*session[K, V]
+
+		result := func(s *session[K, V]) Result[K, A] {
+			value := custom_iter.Fold(slices.Values(s.values), init, foldFn)
+			return Result[K, A]{Key: s.key, Window: s.window, Value: value, Count: uint(len(s.values)), Late: s.superseded}
+		}
+		emit := func(final bool) bool {
+			due = due[:0]
+			for key, list := range sessions {
+				list = slices.DeleteFunc(list, func(s *session[K, V]) bool {
+					return s.fired && !final && !wm.retained(s.window.End)
+				})
+				if len(list) == 0 {
+					delete(sessions, key)
+					continue
+				}
+				sessions[key] = list
+				for _, s := range list {
+					if !s.fired && (final || wm.passed(s.window.End)) {
+						due = append(due, s)
+					}
+				}
+			}
+			slices.SortFunc(due, func(a, b *session[K, V]) int {
+				return compareEmission(a.window, b.window, a.seq, b.seq)
+			})
+			for _, s := range due {
+				s.fired = true
+				if !yield(result(s)) {
+					return false
+				}
+			}
+			return true
+		}
+
+		for r := range records {
+			seq := order.of(r.Key)
+			own := Window{r.Time, r.Time.Add(gap)}
+			late := wm.passed(own.End)
+			if late && !wm.retained(own.End) {
+				if cfg.Late == SideOutputLate {
+					if !yield(Result[K, A]{Key: r.Key, Window: own, Value: foldFn(init, r.Value), Count: 1, Late: true}) {
+						return
+					}
+				}
+				continue
+			}
+
+			merged := &session[K, V]{key: r.Key, seq: seq, window: own}
+			list := sessions[r.Key]
+			list = slices.DeleteFunc(list, func(s *session[K, V]) bool {
+				if !s.window.Start.Before(merged.window.End) || !merged.window.Start.Before(s.window.End) {
+					return false
+				}
+				if s.window.Start.Before(merged.window.Start) {
+					merged.window.Start = s.window.Start
+				}
+				if s.window.End.After(merged.window.End) {
+					merged.window.End = s.window.End
+				}
+				merged.values = append(merged.values, s.values...)
+				merged.superseded = merged.superseded || s.fired || s.superseded
+				return true
+			})
+			merged.values = append(merged.values, r.Value)
+			sessions[r.Key] = append(list, merged)
+			if wm.passed(merged.window.End) {
+				merged.fired, merged.superseded = true, true
+				if !yield(result(merged)) {
+					return
+				}
+			}
+			if wm.observe(r.Time) && !emit(false) {
+				return
+			}
+		}
+		emit(true)
+	}
+}
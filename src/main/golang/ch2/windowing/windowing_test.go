@@ -0,0 +1,128 @@
+package windowing
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+)
+
+var t0 = time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+func at(sec int) time.Time {
+	return t0.Add(time.Duration(sec) * time.Second)
+}
+
+func rec(key string, sec, value int) Record[string, int] {
+	return Record[string, int]{Key: key, Time: at(sec), Value: value}
+}
+
+func window(start, end int) Window {
+	return Window{at(start), at(end)}
+}
+
+func sum(acc, v int) int {
+	return acc + v
+}
+
+func TestTumbling(t *testing.T) {
+	records := []Record[string, int]{
+		rec("a", 1, 1), rec("b", 2, 10), rec("a", 12, 2), rec("a", 8, 3), rec("b", 16, 20), rec("a", 21, 4),
+	}
+	got := slices.Collect(Tumbling(slices.Values(records), 10*time.Second, Config{MaxOutOfOrder: 5 * time.Second}, 0, sum))
+	want := []Result[string, int]{
+		{Key: "a", Window: window(0, 10), Value: 4, Count: 2},
+		{Key: "b", Window: window(0, 10), Value: 10, Count: 1},
+		{Key: "a", Window: window(10, 20), Value: 2, Count: 1},
+		{Key: "b", Window: window(10, 20), Value: 20, Count: 1},
+		{Key: "a", Window: window(20, 30), Value: 4, Count: 1},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v\nwant %v\n", got, want)
+	}
+
+	for range Tumbling(slices.Values(records), 10*time.Second, Config{}, 0, sum) {
+		break
+	}
+}
+
+func TestLatePolicies(t *testing.T) {
+	records := []Record[string, int]{rec("a", 1, 1), rec("a", 15, 2), rec("a", 5, 3), rec("a", 30, 4), rec("a", 7, 5)}
+	for _, tc := range []struct {
+		cfg  Config
+		want []Result[string, int]
+	}{
+		{
+			Config{Late: DropLate},
+			[]Result[string, int]{
+				{Key: "a", Window: window(0, 10), Value: 1, Count: 1},
+				{Key: "a", Window: window(10, 20), Value: 2, Count: 1},
+				{Key: "a", Window: window(30, 40), Value: 4, Count: 1},
+			},
+		},
+		{
+			Config{Late: SideOutputLate},
+			[]Result[string, int]{
+				{Key: "a", Window: window(0, 10), Value: 1, Count: 1},
+				{Key: "a", Window: window(0, 10), Value: 3, Count: 1, Late: true},
+				{Key: "a", Window: window(10, 20), Value: 2, Count: 1},
+				{Key: "a", Window: window(0, 10), Value: 5, Count: 1, Late: true},
+				{Key: "a", Window: window(30, 40), Value: 4, Count: 1},
+			},
+		},
+		{
+			Config{Late: UpdateLate, AllowedLateness: 10 * time.Second},
+			[]Result[string, int]{
+				{Key: "a", Window: window(0, 10), Value: 1, Count: 1},
+				{Key: "a", Window: window(0, 10), Value: 4, Count: 2, Late: true},
+				{Key: "a", Window: window(10, 20), Value: 2, Count: 1},
+				{Key: "a", Window: window(30, 40), Value: 4, Count: 1},
+			},
+		},
+	} {
+		got := slices.Collect(Tumbling(slices.Values(records), 10*time.Second, tc.cfg, 0, sum))
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%v got %v\nwant %v\n", tc.cfg.Late, got, tc.want)
+		}
+	}
+}
+
+func TestHopping(t *testing.T) {
+	records := []Record[string, int]{rec("a", 7, 1), rec("a", 12, 1)}
+	got := slices.Collect(Hopping(slices.Values(records), 10*time.Second, 5*time.Second, Config{}, 0, sum))
+	want := []Result[string, int]{
+		{Key: "a", Window: window(0, 10), Value: 1, Count: 1},
+		{Key: "a", Window: window(5, 15), Value: 2, Count: 2},
+		{Key: "a", Window: window(10, 20), Value: 1, Count: 1},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v\nwant %v\n", got, want)
+	}
+	for _, r := range got {
+		if !r.Window.Contains(at(12)) && !r.Window.Contains(at(7)) {
+			t.Errorf("window %v contains no record\n", r.Window)
+		}
+	}
+}
+
+func TestSession(t *testing.T) {
+	records := []Record[string, int]{rec("a", 0, 1), rec("a", 3, 2), rec("b", 4, 10), rec("a", 20, 3), rec("a", 12, 4)}
+	cfg := Config{Late: UpdateLate, AllowedLateness: 10 * time.Second}
+	got := slices.Collect(Session(slices.Values(records), 5*time.Second, cfg, 0, sum))
+	want := []Result[string, int]{
+		{Key: "a", Window: window(0, 8), Value: 3, Count: 2},
+		{Key: "b", Window: window(4, 9), Value: 10, Count: 1},
+		{Key: "a", Window: window(12, 17), Value: 4, Count: 1, Late: true},
+		{Key: "a", Window: window(20, 25), Value: 3, Count: 1},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v\nwant %v\n", got, want)
+	}
+
+	records = []Record[string, int]{rec("a", 30, 5), rec("a", 38, 6), rec("a", 34, 7)}
+	got = slices.Collect(Session(slices.Values(records), 5*time.Second, Config{MaxOutOfOrder: 10 * time.Second}, 0, sum))
+	want = []Result[string, int]{{Key: "a", Window: window(30, 43), Value: 18, Count: 3}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v\nwant %v\n", got, want)
+	}
+}
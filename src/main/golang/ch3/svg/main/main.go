@@ -2,7 +2,8 @@ package main
 
 import (
 	"examples/ch3/svg"
-	"log"
+	"examples/ch8/logctx"
+	"log/slog"
 	"math"
 	"net/http"
 	"os"
@@ -18,23 +19,26 @@ func main() {
 
 	logfile, err := os.OpenFile("log.txt", os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0666)
 	if err != nil {
-		log.Fatal(err)
+		slog.Error("cannot open log file", "err", err)
+		os.Exit(1)
 	}
-	logger := log.New(logfile, "", log.Ldate|log.Ltime|log.Lshortfile)
+	logger := slog.New(logctx.NewHandler(slog.NewTextHandler(logfile, &slog.HandlerOptions{AddSource: true})))
 	http.HandleFunc("/plot", func(w http.ResponseWriter, r *http.Request) {
 		params := r.URL.Query()
 		idStr := params.Get("id")
 		id, err := strconv.Atoi(idStr)
 		if err != nil || !(0 <= id && id <= len(bpArray)) {
 			http.Error(w, "plot not found", http.StatusBadRequest)
-			logger.Printf("Bad request /plot?id=%v", idStr)
+			logger.WarnContext(r.Context(), "bad request /plot", "id", idStr)
 			return
 		}
 		w.Header().Set("Content-Type", "image/svg+xml")
 		err = bpArray[id].PlotSVG(w)
 		if err != nil {
-			logger.Printf("%+v", err)
+			logger.ErrorContext(r.Context(), "error plotting svg", "err", err)
 		}
 	})
-	log.Fatal(http.ListenAndServe(":8080", nil))
+	err = http.ListenAndServe(":8080", logctx.RequestID(http.DefaultServeMux))
+	logger.Error("server stopped", "err", err)
+	os.Exit(1)
 }
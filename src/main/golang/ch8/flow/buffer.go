@@ -0,0 +1,131 @@
+package flow
+
+import (
+	"context"
+	"fmt"
+)
+
+// Overflow is what a buffer does with a new value when it is full.
+type Overflow int
+
+const (
+	// Suspend blocks the producer until there is room.
+	Suspend Overflow = iota
+	// DropOldest evicts the oldest buffered value. Without a buffer there is nothing to evict, and it acts as DropLatest.
+	DropOldest
+	// DropLatest discards the new value.
+	DropLatest
+)
+
+func (o Overflow) String() string {
+	switch o {
+	case Suspend:
+		return "suspend"
+	case DropOldest:
+		return "drop-oldest"
+	case DropLatest:
+		return "drop-latest"
+	}
+	return fmt.Sprintf("Overflow(%d)", int(o))
+}
+
+// send delivers v to ch according to overflow. ch must have a single sender.
+// Once done is closed the receiver is gone, and send gives up on v. A nil done never closes.
+func send[T any](ctx context.Context, ch chan T, done <-chan struct{}, v T, overflow Overflow) error {
+	if overflow == DropOldest && cap(ch) == 0 {
+		overflow = DropLatest
+	}
+	switch overflow {
+	case DropOldest:
+		for {
+			select {
+			case ch <- v:
+				return nil
+			default:
+			}
+			select {
+			case <-ch:
+			default:
+			}
+		}
+	case DropLatest:
+		select {
+		case ch <- v:
+		default:
+		}
+		return nil
+	}
+	select {
+	case ch <- v:
+		return nil
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// producer is a flow running on its own goroutine. ch is closed once the flow returned.
+type producer[T any] struct {
+	ch       chan T
+	err      error
+	panicked any
+}
+
+func launch[T any](ctx context.Context, f Flow[T], size uint, overflow Overflow) *producer[T] {
+	p := &producer[T]{ch: make(chan T, size)}
+	go func() {
+		defer close(p.ch)
+		p.err, p.panicked = guard(func() error {
+			return f(ctx, func(v T) error {
+				if err := ctx.Err(); err != nil {
+					return err
+				}
+				return send(ctx, p.ch, nil, v, overflow)
+			})
+		})
+	}()
+	return p
+}
+
+// result is the outcome of the flow. It must be called after ch is closed.
+func (p *producer[T]) result() error {
+	if p.panicked != nil {
+		panic(p.panicked)
+	}
+	return p.err
+}
+
+// drain waits for the producer to return. Its context must be done, or ch will be drained to the end.
+func (p *producer[T]) drain() {
+	for range p.ch {
+	}
+}
+
+// collect runs f on its own goroutine, passing every value to emit.
+func collect[T any](ctx context.Context, f Flow[T], size uint, overflow Overflow, emit func(T) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	p := launch(ctx, f, size, overflow)
+	defer func() {
+		cancel()
+		p.drain()
+	}()
+	for v := range p.ch {
+		if err := emit(v); err != nil {
+			return err
+		}
+	}
+	return p.result()
+}
+
+// Buffer runs the producer on its own goroutine, ahead of the collector by up to size values.
+func Buffer[T any](f Flow[T], size uint, overflow Overflow) Flow[T] {
+	return func(ctx context.Context, emit func(T) error) error {
+		return collect(ctx, f, size, overflow, emit)
+	}
+}
+
+// Conflate runs the producer on its own goroutine, and a slow collector only sees the latest value.
+func Conflate[T any](f Flow[T]) Flow[T] {
+	return Buffer(f, 1, DropOldest)
+}
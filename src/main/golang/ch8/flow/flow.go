@@ -0,0 +1,188 @@
+// Package flow provides Kotlin-style reactive streams: the cold Flow, and the hot SharedFlow and StateFlow.
+// A Flow runs its producer anew for every collector, on the collector's goroutine unless an operator such as Buffer
+// moves it to its own. Every goroutine started by an operator is stopped before the collection returns, and a panic
+// in it is rethrown on the collecting goroutine.
+package flow
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"sync"
+)
+
+// Flow is a cold producer: it calls emit for each value until it is done, emit fails or ctx is done.
+// A producer must return the error of emit as soon as it gets one.
+type Flow[T any] func(ctx context.Context, emit func(T) error) error
+
+var errStopped = errors.New("collector stopped")
+
+// Collect runs the producer, calling fn with each value on the calling goroutine.
+func (f Flow[T]) Collect(ctx context.Context, fn func(T) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return f(ctx, func(v T) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		return fn(v)
+	})
+}
+
+// ToSlice collects every value of the flow.
+func (f Flow[T]) ToSlice(ctx context.Context) ([]T, error) {
+	var values []T
+	err := f.Collect(ctx, func(v T) error {
+		values = append(values, v)
+		return nil
+	})
+	return values, err
+}
+
+// Seq collects the flow as an iterator. A failure of the flow is yielded last, paired with the zero value.
+func (f Flow[T]) Seq(ctx context.Context) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		err := f.Collect(ctx, func(v T) error {
+			if !yield(v, nil) {
+				return errStopped
+			}
+			return nil
+		})
+		if err != nil && !errors.Is(err, errStopped) {
+			var zero T
+			yield(zero, err)
+		}
+	}
+}
+
+func Of[T any](values ...T) Flow[T] {
+	return FromSeq(func(yield func(T) bool) {
+		for _, v := range values {
+			if !yield(v) {
+				return
+			}
+		}
+	})
+}
+
+// FromSeq is the flow of the values of it.
+func FromSeq[T any](it iter.Seq[T]) Flow[T] {
+	return func(ctx context.Context, emit func(T) error) error {
+		for v := range it {
+			if err := emit(v); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func Map[T, R any](f Flow[T], mapFn func(T) R) Flow[R] {
+	return func(ctx context.Context, emit func(R) error) error {
+		return f(ctx, func(v T) error {
+			return emit(mapFn(v))
+		})
+	}
+}
+
+func Filter[T any](f Flow[T], pred func(T) bool) Flow[T] {
+	return func(ctx context.Context, emit func(T) error) error {
+		return f(ctx, func(v T) error {
+			if !pred(v) {
+				return nil
+			}
+			return emit(v)
+		})
+	}
+}
+
+// FlatMapLatest collects the flow returned by mapFn for each value, cancelling the previous one as soon as a new
+// value arrives.
+func FlatMapLatest[T, R any](f Flow[T], mapFn func(T) Flow[R]) Flow[R] {
+	type event struct {
+		value    R
+		err      error
+		panicked any
+		done     bool
+		gen      uint
+	}
+	return func(ctx context.Context, emit func(R) error) error {
+		ctx, cancel := context.WithCancel(ctx)
+		up := launch(ctx, f, 0, Suspend)
+		events := make(chan event)
+		var wg sync.WaitGroup
+		defer func() {
+			cancel()
+			up.drain()
+			wg.Wait()
+		}()
+
+		cancelInner := context.CancelFunc(func() {})
+		var gen uint
+		active := false
+		upCh := up.ch
+		for upCh != nil || active {
+			select {
+			case v, ok := <-upCh:
+				if !ok {
+					upCh = nil
+					if err := up.result(); err != nil {
+						return err
+					}
+					continue
+				}
+				cancelInner()
+				gen++
+				active = true
+				innerCtx, innerCancel := context.WithCancel(ctx)
+				cancelInner = innerCancel
+				wg.Add(1)
+				go func(gen uint) {
+					defer wg.Done()
+					defer innerCancel()
+					err, panicked := guard(func() error {
+						return mapFn(v)(innerCtx, func(r R) error {
+							select {
+							case events <- event{value: r, gen: gen}:
+								return nil
+							case <-innerCtx.Done():
+								return innerCtx.Err()
+							}
+						})
+					})
+					select {
+					case events <- event{err: err, panicked: panicked, done: true, gen: gen}:
+					case <-innerCtx.Done():
+					}
+				}(gen)
+			case e := <-events:
+				if e.gen != gen {
+					continue
+				}
+				if e.panicked != nil {
+					panic(e.panicked)
+				}
+				if e.done {
+					active = false
+					if e.err != nil {
+						return e.err
+					}
+					continue
+				}
+				if err := emit(e.value); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+}
+
+// guard runs fn, capturing a panic so that it can be rethrown on the collecting goroutine.
+func guard(fn func() error) (err error, panicked any) {
+	defer func() {
+		panicked = recover()
+	}()
+	return fn(), nil
+}
@@ -0,0 +1,161 @@
+package flow
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+)
+
+// ticks emits values, sleeping for the given delay before each one.
+func ticks(values []int, delays []time.Duration) Flow[int] {
+	return func(ctx context.Context, emit func(int) error) error {
+		for i, v := range values {
+			select {
+			case <-time.After(delays[i]):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			if err := emit(v); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func TestOperators(t *testing.T) {
+	f := Map(Filter(FromSeq(slices.Values([]int{1, 2, 3, 4, 5, 6})), func(i int) bool { return i%2 == 0 }),
+		func(i int) int { return i * 10 })
+	got, err := f.ToSlice(context.Background())
+	if !reflect.DeepEqual(got, []int{20, 40, 60}) || err != nil {
+		t.Errorf("got %v %v want %v %v\n", got, err, []int{20, 40, 60}, nil)
+	}
+
+	errBoom := errors.New("boom")
+	failing := Flow[int](func(ctx context.Context, emit func(int) error) error {
+		if err := emit(1); err != nil {
+			return err
+		}
+		return errBoom
+	})
+	var values []int
+	var errs []error
+	for v, err := range failing.Seq(context.Background()) {
+		values, errs = append(values, v), append(errs, err)
+	}
+	if !reflect.DeepEqual(values, []int{1, 0}) || !reflect.DeepEqual(errs, []error{nil, errBoom}) {
+		t.Errorf("got %v %v want %v %v\n", values, errs, []int{1, 0}, []error{nil, errBoom})
+	}
+}
+
+func TestBuffer(t *testing.T) {
+	stopped := make(chan struct{})
+	endless := Flow[int](func(ctx context.Context, emit func(int) error) error {
+		defer close(stopped)
+		for i := 0; ; i++ {
+			if err := emit(i); err != nil {
+				return err
+			}
+		}
+	})
+	var got []int
+	for v, err := range Buffer(endless, 4, Suspend).Seq(context.Background()) {
+		if err != nil {
+			t.Fatalf("got error %v\n", err)
+		}
+		got = append(got, v)
+		if len(got) == 3 {
+			break
+		}
+	}
+	select {
+	case <-stopped:
+	default:
+		t.Errorf("producer still running after the collection returned\n")
+	}
+	if !reflect.DeepEqual(got, []int{0, 1, 2}) {
+		t.Errorf("got %v want %v\n", got, []int{0, 1, 2})
+	}
+
+	defer func() {
+		if p := recover(); p != "oops" {
+			t.Errorf("got panic %v want %v\n", p, "oops")
+		}
+	}()
+	_ = Buffer(Flow[int](func(context.Context, func(int) error) error { panic("oops") }), 1, Suspend).
+		Collect(context.Background(), func(int) error { return nil })
+	t.Errorf("panic was not rethrown\n")
+}
+
+func TestBufferWithoutCapacity(t *testing.T) {
+	done := make(chan error, 2)
+	go func() {
+		done <- send(context.Background(), make(chan int), nil, 1, DropOldest)
+	}()
+	go func() {
+		done <- Buffer(FromSeq(slices.Values([]int{1, 2, 3})), 0, DropOldest).
+			Collect(context.Background(), func(int) error {
+				time.Sleep(time.Millisecond)
+				return nil
+			})
+	}()
+	for range 2 {
+		select {
+		case err := <-done:
+			if err != nil {
+				t.Errorf("got %v want %v\n", err, nil)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("DropOldest without capacity blocked the producer\n")
+		}
+	}
+}
+
+func TestConflate(t *testing.T) {
+	var got []int
+	err := Conflate(FromSeq(slices.Values([]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}))).
+		Collect(context.Background(), func(v int) error {
+			got = append(got, v)
+			time.Sleep(time.Millisecond)
+			return nil
+		})
+	if err != nil || !slices.IsSorted(got) || got[len(got)-1] != 10 || len(got) == 10 {
+		t.Errorf("got %v %v want a conflated increasing run ending with %v\n", got, err, 10)
+	}
+}
+
+func TestDebounce(t *testing.T) {
+	ms := time.Millisecond
+	f := ticks([]int{1, 2, 3, 4, 5}, []time.Duration{0, ms, ms, 50 * ms, ms})
+	got, err := Debounce(f, 20*ms).ToSlice(context.Background())
+	if !reflect.DeepEqual(got, []int{3, 5}) || err != nil {
+		t.Errorf("got %v %v want %v %v\n", got, err, []int{3, 5}, nil)
+	}
+}
+
+func TestSample(t *testing.T) {
+	values := make([]int, 40)
+	delays := make([]time.Duration, 40)
+	for i := range values {
+		values[i], delays[i] = i, time.Millisecond
+	}
+	got, err := Sample(ticks(values, delays), 10*time.Millisecond).ToSlice(context.Background())
+	if err != nil || len(got) == 0 || len(got) >= len(values) || !slices.IsSorted(got) {
+		t.Errorf("got %v %v want a sparse increasing sample\n", got, err)
+	}
+}
+
+func TestFlatMapLatest(t *testing.T) {
+	ms := time.Millisecond
+	up := Map(ticks([]int{1, 2}, []time.Duration{0, 20 * ms}), func(i int) string { return string(rune('a' + i - 1)) })
+	f := FlatMapLatest(up, func(s string) Flow[string] {
+		return Map(ticks([]int{1, 2}, []time.Duration{0, 50 * ms}), func(i int) string { return s + string(rune('0'+i)) })
+	})
+	got, err := f.ToSlice(context.Background())
+	if want := []string{"a1", "b1", "b2"}; !reflect.DeepEqual(got, want) || err != nil {
+		t.Errorf("got %v %v want %v %v\n", got, err, want, nil)
+	}
+}
@@ -0,0 +1,136 @@
+package flow
+
+import (
+	"context"
+	"iter"
+	"sync"
+)
+
+// SharedFlow is a hot flow: values are emitted whether or not anyone collects, and every active collector sees them.
+// A new collector first receives the last replay values. Collecting a SharedFlow never ends before its context.
+type SharedFlow[T any] struct {
+	replay   uint
+	size     uint
+	overflow Overflow
+
+	emitMu sync.Mutex
+	mu     sync.Mutex
+	cache  []T
+	subs   map[*subscriber[T]]struct{}
+}
+
+type subscriber[T any] struct {
+	ch   chan T
+	done chan struct{}
+}
+
+// NewSharedFlow returns a SharedFlow replaying the last replay values to new collectors.
+// Each collector may lag behind by replay+extraBuffer values before overflow applies to it.
+// Without any buffer, DropOldest and DropLatest only deliver to collectors that are waiting for a value.
+func NewSharedFlow[T any](replay, extraBuffer uint, overflow Overflow) *SharedFlow[T] {
+	return &SharedFlow[T]{
+		replay:   replay,
+		size:     replay + extraBuffer,
+		overflow: overflow,
+		subs:     make(map[*subscriber[T]]struct{}),
+	}
+}
+
+// Emit delivers v to every collector. Under Suspend it waits for slow collectors, or until ctx is done.
+// Concurrent calls are delivered one at a time, so every collector sees the same order.
+func (s *SharedFlow[T]) Emit(ctx context.Context, v T) error {
+	s.emitMu.Lock()
+	defer s.emitMu.Unlock()
+
+	s.mu.Lock()
+	if s.replay > 0 {
+		if uint(len(s.cache)) == s.replay {
+			s.cache = append(s.cache[:0], s.cache[1:]...)
+		}
+		s.cache = append(s.cache, v)
+	}
+	subs := make([]*subscriber[T], 0, len(s.subs))
+	for sub := range s.subs {
+		subs = append(subs, sub)
+	}
+	s.mu.Unlock()
+
+	for _, sub := range subs {
+		if err := send(ctx, sub.ch, sub.done, v, s.overflow); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// EmitAll emits every value of it, stopping at the first failure.
+func (s *SharedFlow[T]) EmitAll(ctx context.Context, it iter.Seq[T]) error {
+	for v := range it {
+		if err := s.Emit(ctx, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SubscriptionCount is the number of active collectors.
+func (s *SharedFlow[T]) SubscriptionCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.subs)
+}
+
+// ReplayCache returns a copy of the values a new collector would receive first.
+func (s *SharedFlow[T]) ReplayCache() []T {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]T(nil), s.cache...)
+}
+
+// Flow subscribes to the SharedFlow when collected, and unsubscribes when the collection returns.
+func (s *SharedFlow[T]) Flow() Flow[T] {
+	return func(ctx context.Context, emit func(T) error) error {
+		sub := &subscriber[T]{ch: make(chan T, s.size), done: make(chan struct{})}
+		s.mu.Lock()
+		replayed := append([]T(nil), s.cache...)
+		s.subs[sub] = struct{}{}
+		s.mu.Unlock()
+		defer func() {
+			s.mu.Lock()
+			delete(s.subs, sub)
+			s.mu.Unlock()
+			close(sub.done)
+		}()
+
+		for _, v := range replayed {
+			if err := emit(v); err != nil {
+				return err
+			}
+		}
+		for {
+			select {
+			case v := <-sub.ch:
+				if err := emit(v); err != nil {
+					return err
+				}
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+}
+
+// Seq iterates the SharedFlow until ctx is done or the loop breaks.
+func (s *SharedFlow[T]) Seq(ctx context.Context) iter.Seq[T] {
+	return hotSeq(ctx, s.Flow())
+}
+
+func hotSeq[T any](ctx context.Context, f Flow[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v, err := range f.Seq(ctx) {
+			if err != nil || !yield(v) {
+				return
+			}
+		}
+	}
+}
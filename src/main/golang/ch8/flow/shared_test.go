@@ -0,0 +1,100 @@
+package flow
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+)
+
+// waitFor polls cond, since subscribing happens on the collecting goroutine.
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	for deadline := time.Now().Add(time.Second); !cond(); {
+		if time.Now().After(deadline) {
+			t.Fatalf("condition not met in time\n")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestSharedFlowReplay(t *testing.T) {
+	ctx := context.Background()
+	s := NewSharedFlow[int](2, 0, Suspend)
+	if err := s.EmitAll(ctx, slices.Values([]int{1, 2, 3})); err != nil {
+		t.Fatalf("got error %v\n", err)
+	}
+	if got := s.ReplayCache(); !reflect.DeepEqual(got, []int{2, 3}) {
+		t.Errorf("got replay %v want %v\n", got, []int{2, 3})
+	}
+
+	results := make(chan []int)
+	for range 2 {
+		go func() {
+			var got []int
+			for v := range s.Seq(ctx) {
+				got = append(got, v)
+				if len(got) == 4 {
+					break
+				}
+			}
+			results <- got
+		}()
+	}
+	waitFor(t, func() bool { return s.SubscriptionCount() == 2 })
+	_ = s.EmitAll(ctx, slices.Values([]int{4, 5}))
+	for range 2 {
+		if got := <-results; !reflect.DeepEqual(got, []int{2, 3, 4, 5}) {
+			t.Errorf("got %v want %v\n", got, []int{2, 3, 4, 5})
+		}
+	}
+	waitFor(t, func() bool { return s.SubscriptionCount() == 0 })
+}
+
+func TestSharedFlowOverflow(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	for _, tc := range []struct {
+		overflow Overflow
+		want     []int
+	}{
+		{DropOldest, []int{4, 5}},
+		{DropLatest, []int{1, 2}},
+	} {
+		s := NewSharedFlow[int](0, 2, tc.overflow)
+		sub := &subscriber[int]{ch: make(chan int, 2), done: make(chan struct{})}
+		s.subs[sub] = struct{}{}
+		_ = s.EmitAll(ctx, slices.Values([]int{1, 2, 3, 4, 5}))
+		got := []int{<-sub.ch, <-sub.ch}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%v got %v want %v\n", tc.overflow, got, tc.want)
+		}
+	}
+
+	for _, overflow := range []Overflow{DropOldest, DropLatest} {
+		s := NewSharedFlow[int](0, 0, overflow)
+		s.subs[&subscriber[int]{ch: make(chan int), done: make(chan struct{})}] = struct{}{}
+		if err := s.Emit(ctx, 1); err != nil {
+			t.Errorf("%v got %v want %v\n", overflow, err, nil)
+		}
+	}
+
+	gone := &subscriber[int]{ch: make(chan int), done: make(chan struct{})}
+	close(gone.done)
+	s := NewSharedFlow[int](0, 0, Suspend)
+	s.subs[gone] = struct{}{}
+	if err := s.Emit(ctx, 1); err != nil {
+		t.Errorf("got %v want %v\n", err, nil)
+	}
+
+	s = NewSharedFlow[int](0, 0, Suspend)
+	go func() { _ = s.Flow().Collect(ctx, func(int) error { <-ctx.Done(); return ctx.Err() }) }()
+	waitFor(t, func() bool { return s.SubscriptionCount() == 1 })
+	_ = s.Emit(ctx, 1)
+	timeout, cancelTimeout := context.WithTimeout(ctx, 5*time.Millisecond)
+	defer cancelTimeout()
+	if err := s.Emit(timeout, 2); err != context.DeadlineExceeded {
+		t.Errorf("got %v want %v\n", err, context.DeadlineExceeded)
+	}
+}
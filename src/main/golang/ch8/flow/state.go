@@ -0,0 +1,105 @@
+package flow
+
+import (
+	"context"
+	"iter"
+	"sync"
+)
+
+// StateFlow is a hot flow holding a single value. Collectors receive the current value, then every change of it.
+// Setting an equal value is not a change, and a slow collector skips to the latest value.
+type StateFlow[T comparable] struct {
+	mu      sync.Mutex
+	value   T
+	changed chan struct{}
+}
+
+func NewStateFlow[T comparable](initial T) *StateFlow[T] {
+	return &StateFlow[T]{value: initial, changed: make(chan struct{})}
+}
+
+func (s *StateFlow[T]) Value() T {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.value
+}
+
+// Set replaces the value, waking up collectors if it changed.
+func (s *StateFlow[T]) Set(v T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.set(v)
+}
+
+// CompareAndSet replaces the value with update only if it is expect, and reports whether it did.
+func (s *StateFlow[T]) CompareAndSet(expect, update T) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.value != expect {
+		return false
+	}
+	s.set(update)
+	return true
+}
+
+// Update atomically replaces the value with updateFn of it, and returns the new value.
+func (s *StateFlow[T]) Update(updateFn func(T) T) T {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.set(updateFn(s.value))
+	return s.value
+}
+
+// set must be called with mu held.
+func (s *StateFlow[T]) set(v T) {
+	if s.value == v {
+		return
+	}
+	s.value = v
+	close(s.changed)
+	s.changed = make(chan struct{})
+}
+
+func (s *StateFlow[T]) current() (T, <-chan struct{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.value, s.changed
+}
+
+// Flow emits the current value when collected, then each new value until ctx is done.
+func (s *StateFlow[T]) Flow() Flow[T] {
+	return func(ctx context.Context, emit func(T) error) error {
+		v, changed := s.current()
+		if err := emit(v); err != nil {
+			return err
+		}
+		last := v
+		for {
+			select {
+			case <-changed:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			v, changed = s.current()
+			if v == last {
+				continue
+			}
+			last = v
+			if err := emit(v); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// Seq iterates the StateFlow until ctx is done or the loop breaks.
+func (s *StateFlow[T]) Seq(ctx context.Context) iter.Seq[T] {
+	return hotSeq(ctx, s.Flow())
+}
+
+// SetAll sets the value to each value of it in turn.
+func (s *StateFlow[T]) SetAll(it iter.Seq[T]) {
+	for v := range it {
+		s.Set(v)
+	}
+}
@@ -0,0 +1,41 @@
+package flow
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestStateFlow(t *testing.T) {
+	s := NewStateFlow(0)
+	if !s.CompareAndSet(0, 1) || s.CompareAndSet(0, 2) || s.Value() != 1 {
+		t.Errorf("CompareAndSet got value %d want %d\n", s.Value(), 1)
+	}
+	if got := s.Update(func(n int) int { return n + 1 }); got != 2 {
+		t.Errorf("Update got %d want %d\n", got, 2)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	received := make(chan int)
+	done := make(chan []int)
+	go func() {
+		var got []int
+		for v := range s.Seq(ctx) {
+			got = append(got, v)
+			received <- v
+		}
+		done <- got
+	}()
+	<-received
+	s.Set(2)
+	s.Set(3)
+	<-received
+	s.Set(3)
+	s.Set(4)
+	<-received
+	cancel()
+	if got := <-done; !reflect.DeepEqual(got, []int{2, 3, 4}) {
+		t.Errorf("got %v want %v\n", got, []int{2, 3, 4})
+	}
+}
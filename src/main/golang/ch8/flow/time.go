@@ -0,0 +1,81 @@
+package flow
+
+import (
+	"context"
+	"time"
+)
+
+// Debounce emits a value only once timeout passed without a newer one. The last value is always emitted.
+func Debounce[T any](f Flow[T], timeout time.Duration) Flow[T] {
+	return func(ctx context.Context, emit func(T) error) error {
+		ctx, cancel := context.WithCancel(ctx)
+		p := launch(ctx, f, 0, Suspend)
+		timer := time.NewTimer(timeout)
+		timer.Stop()
+		defer func() {
+			timer.Stop()
+			cancel()
+			p.drain()
+		}()
+
+		var pending T
+		hasPending := false
+		for {
+			select {
+			case v, ok := <-p.ch:
+				if !ok {
+					if err := p.result(); err != nil {
+						return err
+					}
+					if hasPending {
+						return emit(pending)
+					}
+					return nil
+				}
+				pending, hasPending = v, true
+				timer.Reset(timeout)
+			case <-timer.C:
+				if hasPending {
+					hasPending = false
+					if err := emit(pending); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+}
+
+// Sample emits the latest value once every period, if there was a new one during it.
+// A value arriving after the last full period is not emitted.
+func Sample[T any](f Flow[T], period time.Duration) Flow[T] {
+	return func(ctx context.Context, emit func(T) error) error {
+		ctx, cancel := context.WithCancel(ctx)
+		p := launch(ctx, f, 0, Suspend)
+		ticker := time.NewTicker(period)
+		defer func() {
+			ticker.Stop()
+			cancel()
+			p.drain()
+		}()
+
+		var latest T
+		hasLatest := false
+		for {
+			select {
+			case v, ok := <-p.ch:
+				if !ok {
+					return p.result()
+				}
+				latest, hasLatest = v, true
+			case <-ticker.C:
+				if hasLatest {
+					hasLatest = false
+					if err := emit(latest); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,193 @@
+// Package future provides futures & promises in the style of Kotlin Deferred and JavaScript Promise.
+// Every combinator aggregates the errors of the futures it waits on with errors.Join.
+package future
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"sync"
+	"time"
+)
+
+var ErrNoFutures = errors.New("no futures to await")
+
+type Future[T any] struct {
+	done  chan struct{}
+	once  sync.Once
+	value T
+	err   error
+}
+
+func newFuture[T any]() *Future[T] {
+	return &Future[T]{done: make(chan struct{})}
+}
+
+// complete settles the future once, and reports whether this call settled it.
+func (f *Future[T]) complete(value T, err error) bool {
+	settled := false
+	f.once.Do(func() {
+		f.value, f.err = value, err
+		close(f.done)
+		settled = true
+	})
+	return settled
+}
+
+// Go runs fn on a new goroutine. A panic in fn fails the future instead of crashing the program.
+func Go[T any](fn func() (T, error)) *Future[T] {
+	f := newFuture[T]()
+	go func() {
+		var value T
+		var err error
+		defer func() {
+			if p := recover(); p != nil {
+				var zero T
+				f.complete(zero, fmt.Errorf("future panicked: %v", p))
+				return
+			}
+			f.complete(value, err)
+		}()
+		value, err = fn()
+	}()
+	return f
+}
+
+// Done is closed once the future is settled.
+func (f *Future[T]) Done() <-chan struct{} {
+	return f.done
+}
+
+// Await blocks until the future is settled or ctx is done.
+func (f *Future[T]) Await(ctx context.Context) (T, error) {
+	select {
+	case <-f.done:
+		return f.value, f.err
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+// Seq yields the outcome of the future once it is settled.
+func (f *Future[T]) Seq() iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		<-f.done
+		yield(f.value, f.err)
+	}
+}
+
+// Then chains fn after f succeeds. A failure of f skips fn and fails the returned future with the same error.
+func Then[T, R any](f *Future[T], fn func(T) (R, error)) *Future[R] {
+	return Go(func() (R, error) {
+		<-f.done
+		if f.err != nil {
+			var zero R
+			return zero, f.err
+		}
+		return fn(f.value)
+	})
+}
+
+// WithTimeout fails with context.DeadlineExceeded when f is not settled within timeout.
+func WithTimeout[T any](f *Future[T], timeout time.Duration) *Future[T] {
+	return Go(func() (T, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		return f.Await(ctx)
+	})
+}
+
+// AwaitAll waits for every future and returns their values in order along with all of their errors joined.
+func AwaitAll[T any](ctx context.Context, futures ...*Future[T]) ([]T, error) {
+	values := make([]T, len(futures))
+	errs := make([]error, len(futures))
+	for i, f := range futures {
+		values[i], errs[i] = f.Await(ctx)
+	}
+	return values, errors.Join(errs...)
+}
+
+// AwaitAny returns the first value to succeed. It fails with every error joined only when all futures fail.
+func AwaitAny[T any](ctx context.Context, futures ...*Future[T]) (T, error) {
+	var zero T
+	if len(futures) == 0 {
+		return zero, ErrNoFutures
+	}
+	var errs []error
+	for value, err := range AsCompleted(ctx, futures...) {
+		if err == nil {
+			return value, nil
+		}
+		errs = append(errs, err)
+	}
+	if err := ctx.Err(); err != nil {
+		errs = append(errs, err)
+	}
+	return zero, errors.Join(errs...)
+}
+
+// Race returns the outcome of the first future to settle, whether it succeeded or failed.
+func Race[T any](ctx context.Context, futures ...*Future[T]) (T, error) {
+	for value, err := range AsCompleted(ctx, futures...) {
+		return value, err
+	}
+	var zero T
+	if err := ctx.Err(); err != nil {
+		return zero, err
+	}
+	return zero, ErrNoFutures
+}
+
+// AsCompleted yields the outcome of every future in the order they settle, until ctx is done.
+func AsCompleted[T any](ctx context.Context, futures ...*Future[T]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		settled := make(chan *Future[T], len(futures))
+		for _, f := range futures {
+			go func() {
+				select {
+				case <-f.done:
+					settled <- f
+				case <-ctx.Done():
+				}
+			}()
+		}
+		for range futures {
+			select {
+			case f := <-settled:
+				if !yield(f.value, f.err) {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// Promise is a future settled from the outside.
+type Promise[T any] struct {
+	future *Future[T]
+}
+
+func NewPromise[T any]() *Promise[T] {
+	return &Promise[T]{newFuture[T]()}
+}
+
+func (p *Promise[T]) Future() *Future[T] {
+	return p.future
+}
+
+// Resolve reports false when the promise was already settled.
+func (p *Promise[T]) Resolve(value T) bool {
+	return p.future.complete(value, nil)
+}
+
+// Reject reports false when the promise was already settled.
+func (p *Promise[T]) Reject(err error) bool {
+	var zero T
+	return p.future.complete(zero, err)
+}
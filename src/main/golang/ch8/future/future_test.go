@@ -0,0 +1,100 @@
+package future
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func after[T any](d time.Duration, value T, err error) *Future[T] {
+	return Go(func() (T, error) {
+		time.Sleep(d)
+		return value, err
+	})
+}
+
+func TestAwait(t *testing.T) {
+	got, err := Go(func() (int, error) { return 42, nil }).Await(context.Background())
+	if got != 42 || err != nil {
+		t.Errorf("got %v %v want %v %v\n", got, err, 42, nil)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := after(time.Second, 1, nil).Await(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("got %v want %v\n", err, context.Canceled)
+	}
+	if _, err := Go(func() (int, error) { panic("boom") }).Await(context.Background()); err == nil || !strings.Contains(err.Error(), "boom") {
+		t.Errorf("got %v want panic error\n", err)
+	}
+}
+
+func TestThen(t *testing.T) {
+	errFirst := errors.New("first")
+	got, err := Then(after(0, 2, nil), func(i int) (string, error) { return strings.Repeat("a", i), nil }).Await(context.Background())
+	if got != "aa" || err != nil {
+		t.Errorf("got %v %v want %v %v\n", got, err, "aa", nil)
+	}
+	called := false
+	_, err = Then(after(0, 2, errFirst), func(i int) (int, error) { called = true; return i, nil }).Await(context.Background())
+	if !errors.Is(err, errFirst) || called {
+		t.Errorf("got %v %v want %v %v\n", err, called, errFirst, false)
+	}
+}
+
+func TestAwaitAll(t *testing.T) {
+	errA, errB := errors.New("a"), errors.New("b")
+	got, err := AwaitAll(context.Background(), after(2*time.Millisecond, 1, nil), after(0, 2, nil))
+	if !reflect.DeepEqual(got, []int{1, 2}) || err != nil {
+		t.Errorf("got %v %v want %v %v\n", got, err, []int{1, 2}, nil)
+	}
+	_, err = AwaitAll(context.Background(), after(0, 1, errA), after(0, 2, nil), after(0, 3, errB))
+	if !errors.Is(err, errA) || !errors.Is(err, errB) {
+		t.Errorf("got %v want %v and %v\n", err, errA, errB)
+	}
+}
+
+func TestAwaitAnyRace(t *testing.T) {
+	errFast := errors.New("fast")
+	got, err := AwaitAny(context.Background(), after(0, 1, errFast), after(5*time.Millisecond, 2, nil))
+	if got != 2 || err != nil {
+		t.Errorf("got %v %v want %v %v\n", got, err, 2, nil)
+	}
+	_, err = Race(context.Background(), after(0, 1, errFast), after(50*time.Millisecond, 2, nil))
+	if !errors.Is(err, errFast) {
+		t.Errorf("got %v want %v\n", err, errFast)
+	}
+	_, err = AwaitAny(context.Background(), after(0, 1, errFast), after(0, 2, errFast))
+	if !errors.Is(err, errFast) {
+		t.Errorf("got %v want %v\n", err, errFast)
+	}
+	if _, err := AwaitAny[int](context.Background()); !errors.Is(err, ErrNoFutures) {
+		t.Errorf("got %v want %v\n", err, ErrNoFutures)
+	}
+}
+
+func TestWithTimeout(t *testing.T) {
+	_, err := WithTimeout(after(time.Second, 1, nil), time.Millisecond).Await(context.Background())
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got %v want %v\n", err, context.DeadlineExceeded)
+	}
+}
+
+func TestPromise(t *testing.T) {
+	p := NewPromise[string]()
+	go p.Resolve("done")
+	got, err := p.Future().Await(context.Background())
+	if got != "done" || err != nil {
+		t.Errorf("got %v %v want %v %v\n", got, err, "done", nil)
+	}
+	if p.Reject(errors.New("late")) {
+		t.Errorf("settled promise was rejected\n")
+	}
+	for value, err := range p.Future().Seq() {
+		if value != "done" || err != nil {
+			t.Errorf("got %v %v want %v %v\n", value, err, "done", nil)
+		}
+	}
+}
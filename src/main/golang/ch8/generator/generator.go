@@ -0,0 +1,139 @@
+// Package generator is my Golang implementation of Python generators with send, throw & close.
+// The body is straight-line code that suspends at every yield, which is built on top of iter.Pull.
+package generator
+
+import (
+	"errors"
+	"iter"
+)
+
+var ErrIgnoredClose = errors.New("generator yielded again after Close")
+
+// exit is the panic raised at the suspended yield by Close so that deferred cleanups of the body run.
+type exit struct{}
+
+type Generator[In, Out any] struct {
+	next    func() (Out, bool)
+	stop    func()
+	in      In
+	throw   error
+	thrown  error
+	closing bool
+	started bool
+	done    bool
+	result  Out
+	err     error
+}
+
+// New creates a suspended generator. The body starts running at the first Next, Send or Throw.
+// yield suspends the body with an Out and resumes it with the In passed to Send.
+// When the body returns, its result is available from Result.
+func New[In, Out any](body func(yield func(Out) In) (Out, error)) *Generator[In, Out] {
+	g := &Generator[In, Out]{}
+	g.next, g.stop = iter.Pull(func(yieldPull func(Out) bool) {
+		g.started = true
+		defer func() {
+			g.done = true
+			switch p := recover().(type) {
+			case nil:
+			case exit:
+			case error:
+				if g.thrown == nil || !errors.Is(p, g.thrown) {
+					panic(p)
+				}
+				g.err = p
+			default:
+				panic(p)
+			}
+		}()
+		g.result, g.err = body(func(out Out) In {
+			if g.closing {
+				g.err = ErrIgnoredClose
+				panic(exit{})
+			}
+			g.thrown = nil
+			if !yieldPull(out) {
+				panic(exit{})
+			}
+			if err := g.throw; err != nil {
+				g.throw, g.thrown = nil, err
+				panic(err)
+			}
+			in := g.in
+			var zero In
+			g.in = zero
+			return in
+		})
+	})
+	return g
+}
+
+// Next resumes the body with the zero In and returns what it yields next.
+// It returns false once the body has returned.
+func (g *Generator[In, Out]) Next() (Out, bool) {
+	var zero In
+	return g.Send(zero)
+}
+
+// Send resumes the body with in. The value sent on the resumption that starts the body is discarded.
+func (g *Generator[In, Out]) Send(in In) (Out, bool) {
+	if g.done {
+		var zero Out
+		return zero, false
+	}
+	g.in = in
+	return g.next()
+}
+
+// Throw makes the suspended yield panic with err. The body may recover it and keep going,
+// otherwise the generator finishes and Result reports err.
+// Like Python, throwing into a generator that has not started finishes it with err without running the body.
+func (g *Generator[In, Out]) Throw(err error) (Out, bool) {
+	if g.done {
+		var zero Out
+		return zero, false
+	}
+	if !g.started {
+		g.stop()
+		g.done, g.err = true, err
+		var zero Out
+		return zero, false
+	}
+	g.throw = err
+	return g.next()
+}
+
+// Close finishes a suspended generator, running the deferred cleanups of its body.
+func (g *Generator[In, Out]) Close() error {
+	if g.done {
+		return nil
+	}
+	g.closing = true
+	g.stop()
+	g.done = true
+	if errors.Is(g.err, ErrIgnoredClose) {
+		return g.err
+	}
+	return nil
+}
+
+func (g *Generator[In, Out]) Done() bool {
+	return g.done
+}
+
+// Result returns what the body returned, once the generator is done.
+func (g *Generator[In, Out]) Result() (Out, error) {
+	return g.result, g.err
+}
+
+// All yields everything the generator produces when resumed with Next.
+func (g *Generator[In, Out]) All() iter.Seq[Out] {
+	return func(yield func(Out) bool) {
+		for {
+			out, ok := g.Next()
+			if !ok || !yield(out) {
+				return
+			}
+		}
+	}
+}
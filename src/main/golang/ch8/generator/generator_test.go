@@ -0,0 +1,160 @@
+package generator
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func averager() *Generator[float64, float64] {
+	return New(func(yield func(float64) float64) (float64, error) {
+		var sum, count, average float64
+		for {
+			x := yield(average)
+			if x < 0 {
+				return average, nil
+			}
+			sum += x
+			count++
+			average = sum / count
+		}
+	})
+}
+
+func TestSend(t *testing.T) {
+	g := averager()
+	if got, ok := g.Next(); got != 0 || !ok {
+		t.Errorf("got %v %v want %v %v\n", got, ok, 0, true)
+	}
+	var got []float64
+	for _, x := range []float64{10, 20, 60} {
+		avg, _ := g.Send(x)
+		got = append(got, avg)
+	}
+	if want := []float64{10, 15, 30}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v want %v\n", got, want)
+	}
+	if _, ok := g.Send(-1); ok || !g.Done() {
+		t.Errorf("got %v %v want %v %v\n", ok, g.Done(), false, true)
+	}
+	if result, err := g.Result(); result != 30 || err != nil {
+		t.Errorf("got %v %v want %v %v\n", result, err, 30, nil)
+	}
+}
+
+func TestThrow(t *testing.T) {
+	errSkip, errFatal := errors.New("skip"), errors.New("fatal")
+	g := New(func(yield func(int) struct{}) (int, error) {
+		count := 0
+		for {
+			func() {
+				defer func() {
+					if p := recover(); p != nil && p != errSkip {
+						panic(p)
+					}
+				}()
+				yield(count)
+				count++
+			}()
+		}
+	})
+	g.Next()
+	g.Next()
+	if got, ok := g.Throw(errSkip); got != 1 || !ok {
+		t.Errorf("got %v %v want %v %v\n", got, ok, 1, true)
+	}
+	if _, ok := g.Throw(errFatal); ok {
+		t.Errorf("got %v want %v\n", ok, false)
+	}
+	if _, err := g.Result(); err != errFatal {
+		t.Errorf("got %v want %v\n", err, errFatal)
+	}
+}
+
+func TestThrowBeforeStart(t *testing.T) {
+	errBoom := errors.New("boom")
+	ran := false
+	g := New(func(yield func(int) struct{}) (int, error) {
+		ran = true
+		yield(1)
+		return 0, nil
+	})
+	if _, ok := g.Throw(errBoom); ok {
+		t.Errorf("got %v want %v\n", ok, false)
+	}
+	if _, err := g.Result(); err != errBoom || ran || !g.Done() {
+		t.Errorf("got %v ran %v done %v want %v ran %v done %v\n", err, ran, g.Done(), errBoom, false, true)
+	}
+	if _, ok := g.Next(); ok {
+		t.Errorf("got %v want %v\n", ok, false)
+	}
+}
+
+func TestClose(t *testing.T) {
+	var log []string
+	g := New(func(yield func(string) struct{}) (string, error) {
+		defer func() { log = append(log, "cleanup") }()
+		for _, s := range []string{"a", "b", "c"} {
+			yield(s)
+		}
+		return "", nil
+	})
+	g.Next()
+	if err := g.Close(); err != nil || !reflect.DeepEqual(log, []string{"cleanup"}) || !g.Done() {
+		t.Errorf("got %v %v %v want %v %v %v\n", err, log, g.Done(), nil, []string{"cleanup"}, true)
+	}
+	if _, ok := g.Next(); ok {
+		t.Errorf("got %v want %v\n", ok, false)
+	}
+
+	stubborn := New(func(yield func(int) struct{}) (int, error) {
+		defer func() {
+			recover()
+			yield(2)
+		}()
+		yield(1)
+		return 0, nil
+	})
+	stubborn.Next()
+	if err := stubborn.Close(); !errors.Is(err, ErrIgnoredClose) {
+		t.Errorf("got %v want %v\n", err, ErrIgnoredClose)
+	}
+}
+
+func TestParser(t *testing.T) {
+	// a line parser written as straight-line code: it receives characters and yields completed words
+	parser := New(func(yield func([]string) rune) ([]string, error) {
+		var words []string
+		var word strings.Builder
+		for r := yield(nil); r != '\n'; r = yield(words) {
+			if r == ' ' {
+				words = append(words, word.String())
+				word.Reset()
+				continue
+			}
+			word.WriteRune(r)
+		}
+		return append(words, word.String()), nil
+	})
+	parser.Next()
+	for _, r := range "go is fun\n" {
+		parser.Send(r)
+	}
+	if got, err := parser.Result(); !slices.Equal(got, []string{"go", "is", "fun"}) || err != nil {
+		t.Errorf("got %v %v want %v %v\n", got, err, []string{"go", "is", "fun"}, nil)
+	}
+}
+
+func TestAll(t *testing.T) {
+	g := New(func(yield func(int) struct{}) (int, error) {
+		for i := range 3 {
+			yield(i)
+		}
+		return 0, nil
+	})
+	if got := slices.Collect(g.All()); !reflect.DeepEqual(got, []int{0, 1, 2}) {
+		t.Errorf("got %v want %v\n", got, []int{0, 1, 2})
+	}
+}
@@ -0,0 +1,122 @@
+// Package logctx carries logging fields in a context.Context, like the MDC of kotlin-logging.
+// Attributes attached with WithLogAttrs are added to every record logged with that context (or a child of it)
+// through a Handler, so goroutines spawned with the context inherit them.
+package logctx
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"net/http"
+	"slices"
+)
+
+type attrsKey struct{}
+
+// WithLogAttrs returns a child of ctx carrying attrs in addition to the attributes already in ctx.
+func WithLogAttrs(ctx context.Context, attrs ...slog.Attr) context.Context {
+	if len(attrs) == 0 {
+		return ctx
+	}
+	parent := Attrs(ctx)
+	merged := make([]slog.Attr, 0, len(parent)+len(attrs))
+	merged = append(merged, parent...)
+	merged = append(merged, attrs...)
+	return context.WithValue(ctx, attrsKey{}, merged)
+}
+
+// WithLogArgs is WithLogAttrs taking alternating keys and values, as slog.Logger.Info does.
+func WithLogArgs(ctx context.Context, args ...any) context.Context {
+	return WithLogAttrs(ctx, slog.Group("", args...).Value.Group()...)
+}
+
+// Attrs returns the attributes carried by ctx. The result must not be modified.
+func Attrs(ctx context.Context) []slog.Attr {
+	attrs, _ := ctx.Value(attrsKey{}).([]slog.Attr)
+	return attrs
+}
+
+// Handler adds the attributes of the record's context to every record before passing it to the wrapped handler.
+// Context attributes are always top-level, even when the logger was derived with WithGroup.
+type Handler struct {
+	inner slog.Handler
+	// base is the wrapped handler before any group, and derive replays WithAttrs and WithGroup on top of it.
+	base   slog.Handler
+	derive []func(slog.Handler) slog.Handler
+	group  bool
+}
+
+func NewHandler(inner slog.Handler) *Handler {
+	return &Handler{inner: inner, base: inner}
+}
+
+func (h *Handler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.inner.Enabled(ctx, level)
+}
+
+func (h *Handler) Handle(ctx context.Context, record slog.Record) error {
+	attrs := Attrs(ctx)
+	if len(attrs) == 0 {
+		return h.inner.Handle(ctx, record)
+	}
+	if !h.group {
+		record = record.Clone()
+		record.AddAttrs(attrs...)
+		return h.inner.Handle(ctx, record)
+	}
+	inner := h.base.WithAttrs(attrs)
+	for _, derive := range h.derive {
+		inner = derive(inner)
+	}
+	return inner.Handle(ctx, record)
+}
+
+func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	return h.with(h.inner.WithAttrs(attrs), false, func(inner slog.Handler) slog.Handler {
+		return inner.WithAttrs(attrs)
+	})
+}
+
+func (h *Handler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return h.with(h.inner.WithGroup(name), true, func(inner slog.Handler) slog.Handler {
+		return inner.WithGroup(name)
+	})
+}
+
+func (h *Handler) with(inner slog.Handler, group bool, derive func(slog.Handler) slog.Handler) *Handler {
+	return &Handler{
+		inner:  inner,
+		base:   h.base,
+		derive: append(slices.Clip(h.derive), derive),
+		group:  h.group || group,
+	}
+}
+
+const RequestIDHeader = "X-Request-Id"
+
+// RequestID tags the request context with a "request_id" attribute, taken from the X-Request-Id header when the
+// client sent one and generated otherwise, and echoes it in the response header.
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		id := request.Header.Get(RequestIDHeader)
+		if id == "" {
+			id = newRequestID()
+		}
+		writer.Header().Set(RequestIDHeader, id)
+		ctx := WithLogAttrs(request.Context(), slog.String("request_id", id))
+		next.ServeHTTP(writer, request.WithContext(ctx))
+	})
+}
+
+func newRequestID() string {
+	var buf [8]byte
+	_, _ = rand.Read(buf[:])
+	return hex.EncodeToString(buf[:])
+}
@@ -0,0 +1,96 @@
+package logctx
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func newLogger(buf *bytes.Buffer) *slog.Logger {
+	inner := slog.NewTextHandler(buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey && len(groups) == 0 {
+				return slog.Attr{}
+			}
+			return a
+		},
+	})
+	return slog.New(NewHandler(inner))
+}
+
+func TestWithLogAttrs(t *testing.T) {
+	var buf bytes.Buffer
+	logger := newLogger(&buf)
+	ctx := WithLogAttrs(context.Background(), slog.String("context", "main"))
+	child := WithLogArgs(ctx, "context", "launch", "n", 1)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func(ctx context.Context) {
+		defer wg.Done()
+		logger.InfoContext(ctx, "launch")
+	}(child)
+	wg.Wait()
+	logger.With("static", true).InfoContext(ctx, "main")
+	logger.Info("bare")
+
+	want := strings.Join([]string{
+		`level=INFO msg=launch context=main context=launch n=1`,
+		`level=INFO msg=main static=true context=main`,
+		`level=INFO msg=bare`,
+		``,
+	}, "\n")
+	if got := buf.String(); got != want {
+		t.Errorf("got\n%s\nwant\n%s\n", got, want)
+	}
+	if got := len(Attrs(ctx)); got != 1 {
+		t.Errorf("parent context got %d attrs want %d\n", got, 1)
+	}
+}
+
+func TestWithGroup(t *testing.T) {
+	var buf bytes.Buffer
+	logger := newLogger(&buf).With("static", true).WithGroup("g").With("a", 1)
+	ctx := WithLogAttrs(context.Background(), slog.String("request_id", "x"))
+	logger.InfoContext(ctx, "grouped", "b", 2)
+	logger.Info("bare", "b", 3)
+
+	want := strings.Join([]string{
+		`level=INFO msg=grouped request_id=x static=true g.a=1 g.b=2`,
+		`level=INFO msg=bare static=true g.a=1 g.b=3`,
+		``,
+	}, "\n")
+	if got := buf.String(); got != want {
+		t.Errorf("got\n%s\nwant\n%s\n", got, want)
+	}
+}
+
+func TestRequestID(t *testing.T) {
+	var buf bytes.Buffer
+	logger := newLogger(&buf)
+	handler := RequestID(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		logger.InfoContext(request.Context(), "handled")
+	}))
+
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	request.Header.Set(RequestIDHeader, "abc")
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	if got := recorder.Header().Get(RequestIDHeader); got != "abc" {
+		t.Errorf("got header %q want %q\n", got, "abc")
+	}
+	if got, want := buf.String(), "level=INFO msg=handled request_id=abc\n"; got != want {
+		t.Errorf("got %q want %q\n", got, want)
+	}
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+	if got := recorder.Header().Get(RequestIDHeader); len(got) != 16 {
+		t.Errorf("got generated id %q want 16 hex digits\n", got)
+	}
+}
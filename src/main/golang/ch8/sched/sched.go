@@ -0,0 +1,250 @@
+// Package sched is a cooperative, deterministic scheduler for testing concurrent code.
+// Tasks are iter.Pull coroutines, so only one of them runs at a time, and they switch only at the yield points of
+// this package: Task.Yield, Task.Sleep and the operations on Chan, Timer and Mutex.
+// At each switch the next task is picked at random from a seeded source, and time only moves on a virtual clock,
+// so a run is entirely determined by its seed: a failure found by Explore is replayed by New with the same seed.
+package sched
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"math/rand/v2"
+	"strings"
+	"time"
+)
+
+var (
+	ErrDeadlock  = errors.New("all tasks are blocked")
+	ErrStepLimit = errors.New("step limit exceeded")
+)
+
+// errStopped unwinds a task that the scheduler abandoned after a failure.
+var errStopped = errors.New("task stopped")
+
+// Epoch is the virtual time at which every run starts.
+var Epoch = time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+type Scheduler struct {
+	// MaxSteps bounds the number of task switches of a run, to catch livelocks. 0 means unlimited.
+	MaxSteps uint
+
+	seed  uint64
+	rng   *rand.Rand
+	now   time.Time
+	tasks []*Task
+	trace []string
+}
+
+func New(seed uint64) *Scheduler {
+	return &Scheduler{
+		seed: seed,
+		rng:  rand.New(rand.NewPCG(seed, seed)),
+		now:  Epoch,
+	}
+}
+
+func (s *Scheduler) Seed() uint64 {
+	return s.seed
+}
+
+func (s *Scheduler) Now() time.Time {
+	return s.now
+}
+
+// Trace is the name of the task resumed at each step so far.
+func (s *Scheduler) Trace() []string {
+	return s.trace
+}
+
+type Task struct {
+	s    *Scheduler
+	name string
+	next func() (struct{}, bool)
+	stop func()
+
+	yield   func(struct{}) bool
+	ready   func() bool
+	wakeAt  time.Time
+	waiting bool
+}
+
+// Go adds a task running fn. It may be called before Run, or by a running task.
+func (s *Scheduler) Go(name string, fn func(t *Task)) {
+	t := &Task{s: s, name: name}
+	t.next, t.stop = iter.Pull(func(yield func(struct{}) bool) {
+		defer func() {
+			if p := recover(); p != nil && p != errStopped {
+				panic(p)
+			}
+		}()
+		t.yield = yield
+		fn(t)
+	})
+	s.tasks = append(s.tasks, t)
+}
+
+func (t *Task) Name() string {
+	return t.name
+}
+
+func (t *Task) Now() time.Time {
+	return t.s.now
+}
+
+// Go adds a task to the scheduler of t.
+func (t *Task) Go(name string, fn func(t *Task)) {
+	t.s.Go(name, fn)
+}
+
+// park suspends the task until ready reports true. A nil ready only lets other tasks run.
+func (t *Task) park(ready func() bool) {
+	t.ready = ready
+	if !t.yield(struct{}{}) {
+		panic(errStopped)
+	}
+	t.ready = nil
+	t.waiting = false
+}
+
+// Yield lets the scheduler run another task.
+func (t *Task) Yield() {
+	t.park(nil)
+}
+
+// Sleep suspends the task for d of virtual time.
+func (t *Task) Sleep(d time.Duration) {
+	t.sleep(d, func() bool { return false })
+}
+
+// sleep is Sleep that also wakes up as soon as interrupted reports true, without moving the clock.
+func (t *Task) sleep(d time.Duration, interrupted func() bool) {
+	t.wakeAt, t.waiting = t.s.now.Add(d), true
+	wakeAt := t.wakeAt
+	t.park(func() bool { return interrupted() || !t.s.now.Before(wakeAt) })
+}
+
+func (t *Task) runnable() bool {
+	return t.ready == nil || t.ready()
+}
+
+// Failure is the outcome of a failed run, with what is needed to replay it.
+type Failure struct {
+	Seed  uint64
+	Step  int
+	Task  string
+	Err   error
+	Trace []string
+}
+
+func (f *Failure) Error() string {
+	if f.Task != "" {
+		return fmt.Sprintf("seed %d, step %d, task %q: %v", f.Seed, f.Step, f.Task, f.Err)
+	}
+	return fmt.Sprintf("seed %d, step %d: %v", f.Seed, f.Step, f.Err)
+}
+
+func (f *Failure) Unwrap() error {
+	return f.Err
+}
+
+// Run runs the tasks until all of them returned. When every task is asleep, the clock jumps to the earliest wake-up.
+// A panic in a task, a deadlock or exceeding MaxSteps stops every task and is reported as a *Failure.
+func (s *Scheduler) Run() error {
+	var runnable []*Task
+	for len(s.tasks) > 0 {
+		runnable = runnable[:0]
+		for _, t := range s.tasks {
+			if t.runnable() {
+				runnable = append(runnable, t)
+			}
+		}
+		if len(runnable) == 0 {
+			if !s.advance() {
+				return s.fail("", fmt.Errorf("%w: %s", ErrDeadlock, s.blocked()))
+			}
+			continue
+		}
+		if s.MaxSteps > 0 && uint(len(s.trace)) >= s.MaxSteps {
+			return s.fail("", ErrStepLimit)
+		}
+
+		t := runnable[s.rng.IntN(len(runnable))]
+		s.trace = append(s.trace, t.name)
+		alive, panicked := resume(t)
+		if panicked != nil {
+			s.remove(t)
+			return s.fail(t.name, fmt.Errorf("panic: %v", panicked))
+		}
+		if !alive {
+			s.remove(t)
+		}
+	}
+	return nil
+}
+
+func resume(t *Task) (alive bool, panicked any) {
+	defer func() {
+		panicked = recover()
+	}()
+	_, alive = t.next()
+	return alive, nil
+}
+
+// advance moves the clock to the earliest wake-up of a sleeping task, and reports whether there was one.
+func (s *Scheduler) advance() bool {
+	var earliest time.Time
+	found := false
+	for _, t := range s.tasks {
+		if t.waiting && (!found || t.wakeAt.Before(earliest)) {
+			earliest, found = t.wakeAt, true
+		}
+	}
+	if found {
+		s.now = earliest
+	}
+	return found
+}
+
+func (s *Scheduler) blocked() string {
+	names := make([]string, len(s.tasks))
+	for i, t := range s.tasks {
+		names[i] = t.name
+	}
+	return strings.Join(names, ", ")
+}
+
+func (s *Scheduler) remove(t *Task) {
+	for i, other := range s.tasks {
+		if other == t {
+			s.tasks = append(s.tasks[:i], s.tasks[i+1:]...)
+			return
+		}
+	}
+}
+
+func (s *Scheduler) fail(task string, err error) *Failure {
+	for _, t := range s.tasks {
+		t.stop()
+	}
+	s.tasks = nil
+	return &Failure{Seed: s.seed, Step: len(s.trace), Task: task, Err: err, Trace: s.trace}
+}
+
+// Explore runs test with a new scheduler for each seed in [0, runs), and returns the first failure.
+// test adds tasks, calls Run and checks the outcome. An error of test that is not a *Failure is wrapped in one.
+func Explore(runs uint64, test func(s *Scheduler) error) error {
+	for seed := range runs {
+		s := New(seed)
+		err := test(s)
+		if err == nil {
+			continue
+		}
+		var f *Failure
+		if errors.As(err, &f) {
+			return f
+		}
+		return &Failure{Seed: seed, Step: len(s.trace), Err: err, Trace: s.trace}
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+package sched
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fanIn merges three producers into one unbuffered channel and returns the order of the received values.
+func fanIn(s *Scheduler) ([]string, error) {
+	out := NewChan[string](0)
+	var wg WaitGroup
+	for _, name := range []string{"a", "b", "c"} {
+		wg.Add(1)
+		s.Go(name, func(t *Task) {
+			defer wg.Done()
+			for i := range 2 {
+				out.Send(t, fmt.Sprint(name, i))
+			}
+		})
+	}
+	s.Go("closer", func(t *Task) {
+		wg.Wait(t)
+		out.Close()
+	})
+	var got []string
+	s.Go("consumer", func(t *Task) {
+		for v := range out.All(t) {
+			got = append(got, v)
+		}
+	})
+	return got, s.Run()
+}
+
+func TestDeterminism(t *testing.T) {
+	outcomes := make(map[string]bool)
+	for seed := range uint64(20) {
+		first, err := fanIn(New(seed))
+		if err != nil {
+			t.Fatalf("seed %d: %v\n", seed, err)
+		}
+		again, _ := fanIn(New(seed))
+		if !reflect.DeepEqual(first, again) {
+			t.Errorf("seed %d got %v then %v\n", seed, first, again)
+		}
+		sorted := slices.Sorted(slices.Values(first))
+		if want := []string{"a0", "a1", "b0", "b1", "c0", "c1"}; !reflect.DeepEqual(sorted, want) {
+			t.Errorf("seed %d got %v want a permutation of %v\n", seed, first, want)
+		}
+		outcomes[strings.Join(first, ",")] = true
+	}
+	if len(outcomes) < 2 {
+		t.Errorf("got %d interleavings over 20 seeds want several\n", len(outcomes))
+	}
+}
+
+func TestVirtualClock(t *testing.T) {
+	s := New(0)
+	var woke []time.Duration
+	s.Go("sleeper", func(t *Task) {
+		t.Sleep(time.Hour)
+		woke = append(woke, t.Now().Sub(Epoch))
+	})
+	s.Go("timers", func(t *Task) {
+		stopped := NewTimer(t, time.Minute)
+		fired := After(t, 2*time.Minute)
+		if !stopped.Stop() {
+			panic("timer fired before Stop")
+		}
+		at, _ := fired.Recv(t)
+		woke = append(woke, at.Sub(Epoch))
+	})
+	start := time.Now()
+	if err := s.Run(); err != nil {
+		t.Fatal(err)
+	}
+	if want := []time.Duration{2 * time.Minute, time.Hour}; !reflect.DeepEqual(woke, want) {
+		t.Errorf("got %v want %v\n", woke, want)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("virtual hour took %v of real time\n", elapsed)
+	}
+}
+
+func TestStoppedTimerKeepsClock(t *testing.T) {
+	s := New(0)
+	s.Go("stopper", func(t *Task) {
+		NewTimer(t, time.Hour).Stop()
+		t.Sleep(time.Second)
+	})
+	if err := s.Run(); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := s.Now().Sub(Epoch), time.Second; got != want {
+		t.Errorf("got %v want %v\n", got, want)
+	}
+}
+
+func TestExploreAndReplay(t *testing.T) {
+	lostUpdate := func(mu *Mutex) func(s *Scheduler) error {
+		return func(s *Scheduler) error {
+			counter := 0
+			for i := range 2 {
+				s.Go(fmt.Sprint("incr", i), func(t *Task) {
+					if mu != nil {
+						mu.Lock(t)
+						defer mu.Unlock(t)
+					}
+					x := counter
+					t.Yield()
+					counter = x + 1
+				})
+			}
+			if err := s.Run(); err != nil {
+				return err
+			}
+			if counter != 2 {
+				return fmt.Errorf("counter is %d", counter)
+			}
+			return nil
+		}
+	}
+
+	err := Explore(50, lostUpdate(nil))
+	var f *Failure
+	if !errors.As(err, &f) {
+		t.Fatalf("got %v want a lost update\n", err)
+	}
+	s := New(f.Seed)
+	replayed := lostUpdate(nil)(s)
+	if replayed == nil || replayed.Error() != f.Err.Error() || !reflect.DeepEqual(s.Trace(), f.Trace) {
+		t.Errorf("replay of seed %d got %v %v want %v %v\n", f.Seed, replayed, s.Trace(), f.Err, f.Trace)
+	}
+
+	if err := Explore(50, lostUpdate(&Mutex{})); err != nil {
+		t.Errorf("got %v with a mutex\n", err)
+	}
+}
+
+func TestFailures(t *testing.T) {
+	s := New(0)
+	s.Go("receiver", func(t *Task) {
+		NewChan[int](0).Recv(t)
+	})
+	if err := s.Run(); !errors.Is(err, ErrDeadlock) {
+		t.Errorf("got %v want %v\n", err, ErrDeadlock)
+	}
+
+	s = New(0)
+	cleaned := false
+	s.Go("waiter", func(t *Task) {
+		defer func() { cleaned = true }()
+		t.Sleep(time.Hour)
+	})
+	s.Go("panicker", func(t *Task) {
+		panic("boom")
+	})
+	var f *Failure
+	if err := s.Run(); !errors.As(err, &f) || f.Task != "panicker" || !strings.Contains(f.Error(), "boom") {
+		t.Errorf("got %v want a panic of %q\n", err, "panicker")
+	}
+	if !cleaned {
+		t.Errorf("remaining task was not stopped\n")
+	}
+
+	s = New(0)
+	s.MaxSteps = 100
+	s.Go("spinner", func(t *Task) {
+		for {
+			t.Yield()
+		}
+	})
+	if err := s.Run(); !errors.Is(err, ErrStepLimit) {
+		t.Errorf("got %v want %v\n", err, ErrStepLimit)
+	}
+}
@@ -0,0 +1,156 @@
+package sched
+
+import (
+	"iter"
+	"time"
+)
+
+// Chan is a channel between tasks of one scheduler. Every operation is a yield point.
+type Chan[T any] struct {
+	size   uint
+	buf    []T
+	sent   uint64
+	recvd  uint64
+	closed bool
+}
+
+// NewChan returns a channel buffering size values. With size 0, Send waits for a Recv, as with an unbuffered chan.
+func NewChan[T any](size uint) *Chan[T] {
+	return &Chan[T]{size: size}
+}
+
+func (c *Chan[T]) Send(t *Task, v T) {
+	if c.size > 0 {
+		t.park(func() bool { return c.closed || uint(len(c.buf)) < c.size })
+	} else {
+		t.park(func() bool { return c.closed || len(c.buf) == 0 })
+	}
+	if c.closed {
+		panic("send on closed channel")
+	}
+	c.buf = append(c.buf, v)
+	c.sent++
+	if c.size == 0 {
+		id := c.sent
+		t.park(func() bool { return c.recvd >= id })
+	}
+}
+
+// Recv returns the next value, or the zero value and false once the channel is closed and empty.
+func (c *Chan[T]) Recv(t *Task) (T, bool) {
+	t.park(func() bool { return c.closed || len(c.buf) > 0 })
+	if len(c.buf) == 0 {
+		var zero T
+		return zero, false
+	}
+	v := c.buf[0]
+	c.buf = c.buf[1:]
+	c.recvd++
+	return v, true
+}
+
+// TryRecv returns the next value without waiting for one.
+func (c *Chan[T]) TryRecv(t *Task) (T, bool) {
+	t.Yield()
+	if len(c.buf) == 0 {
+		var zero T
+		return zero, false
+	}
+	v := c.buf[0]
+	c.buf = c.buf[1:]
+	c.recvd++
+	return v, true
+}
+
+func (c *Chan[T]) Close() {
+	if c.closed {
+		panic("close of closed channel")
+	}
+	c.closed = true
+}
+
+func (c *Chan[T]) Len() int {
+	return len(c.buf)
+}
+
+// All receives values until the channel is closed.
+func (c *Chan[T]) All(t *Task) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for {
+			v, ok := c.Recv(t)
+			if !ok || !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Timer delivers the virtual time to C once its duration elapsed, unless it is stopped first.
+type Timer struct {
+	C       *Chan[time.Time]
+	stopped bool
+	fired   bool
+}
+
+// NewTimer starts a timer as a task of the scheduler of t.
+func NewTimer(t *Task, d time.Duration) *Timer {
+	timer := &Timer{C: NewChan[time.Time](1)}
+	t.Go(t.name+"/timer", func(t *Task) {
+		t.sleep(d, func() bool { return timer.stopped })
+		if !timer.stopped {
+			timer.fired = true
+			timer.C.Send(t, t.Now())
+		}
+	})
+	return timer
+}
+
+// Stop prevents the timer from firing, and reports whether it did. A stopped timer no longer holds back the clock.
+func (timer *Timer) Stop() bool {
+	stopped := !timer.stopped && !timer.fired
+	timer.stopped = true
+	return stopped
+}
+
+// After is NewTimer(t, d).C.
+func After(t *Task, d time.Duration) *Chan[time.Time] {
+	return NewTimer(t, d).C
+}
+
+// Mutex is a mutual exclusion lock between tasks of one scheduler.
+type Mutex struct {
+	locked bool
+}
+
+func (m *Mutex) Lock(t *Task) {
+	t.park(func() bool { return !m.locked })
+	m.locked = true
+}
+
+func (m *Mutex) Unlock(t *Task) {
+	if !m.locked {
+		panic("unlock of unlocked mutex")
+	}
+	m.locked = false
+	t.Yield()
+}
+
+// WaitGroup waits for a collection of tasks to finish.
+type WaitGroup struct {
+	n int
+}
+
+func (wg *WaitGroup) Add(delta int) {
+	wg.n += delta
+	if wg.n < 0 {
+		panic("negative WaitGroup counter")
+	}
+}
+
+func (wg *WaitGroup) Done() {
+	wg.Add(-1)
+}
+
+func (wg *WaitGroup) Wait(t *Task) {
+	t.park(func() bool { return wg.n == 0 })
+}
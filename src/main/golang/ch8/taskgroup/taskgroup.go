@@ -0,0 +1,228 @@
+// Package taskgroup provides structured concurrency in the style of Kotlin runBlocking/launch.
+// Tasks started on a Group cannot outlive it: Wait (or Run) returns only after every task returned.
+package taskgroup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"time"
+)
+
+type Policy int
+
+const (
+	// FailFast cancels the siblings of the first failing task, and Wait reports that failure.
+	FailFast Policy = iota
+	// CollectAll lets every task run to completion, and Wait reports all failures joined.
+	CollectAll
+	// Restart reruns a failing or panicking task after Config.Backoff, and fails fast once its restarts run out.
+	// A panic is rethrown by Wait only when its task is not restarted again.
+	Restart
+)
+
+func (p Policy) String() string {
+	switch p {
+	case FailFast:
+		return "fail-fast"
+	case CollectAll:
+		return "collect-all"
+	case Restart:
+		return "restart"
+	}
+	return fmt.Sprintf("Policy(%d)", int(p))
+}
+
+// Backoff is the delay schedule of the Restart policy.
+// The n-th restart waits Initial * Factor^n, capped by Max when Max is positive.
+// MaxRestarts 0 restarts until the group is cancelled.
+type Backoff struct {
+	Initial     time.Duration
+	Max         time.Duration
+	Factor      float64
+	MaxRestarts uint
+}
+
+func (b Backoff) delay(restart uint) time.Duration {
+	factor := b.Factor
+	if factor < 1 {
+		factor = 2
+	}
+	d := float64(b.Initial)
+	for range restart {
+		d *= factor
+		if b.Max > 0 && d >= float64(b.Max) {
+			return b.Max
+		}
+	}
+	return time.Duration(d)
+}
+
+type Config struct {
+	Policy  Policy
+	Backoff Backoff
+	// Limit is the maximum number of tasks running at once. 0 means unlimited. The body of Run does not count.
+	Limit uint
+}
+
+// TaskError is the failure of a named task.
+type TaskError struct {
+	Name string
+	Err  error
+}
+
+func (e *TaskError) Error() string {
+	return fmt.Sprintf("task %q: %v", e.Name, e.Err)
+}
+
+func (e *TaskError) Unwrap() error {
+	return e.Err
+}
+
+// PanicError is a panic captured in a named task, rethrown by Wait on the waiting goroutine.
+type PanicError struct {
+	Name  string
+	Value any
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("task %q panicked: %v\n\n%s", e.Name, e.Value, e.Stack)
+}
+
+type Group struct {
+	cfg    Config
+	ctx    context.Context
+	cancel context.CancelCauseFunc
+	sem    chan struct{}
+	wg     sync.WaitGroup
+
+	mu       sync.Mutex
+	errs     []error
+	panicked *PanicError
+}
+
+// New returns a group and the context its tasks run with.
+// The context is cancelled when a task fails under FailFast or Restart, when a task panics, or when Wait returns.
+func New(ctx context.Context, cfg Config) (*Group, context.Context) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	g := &Group{cfg: cfg, ctx: ctx, cancel: cancel}
+	if cfg.Limit > 0 {
+		g.sem = make(chan struct{}, cfg.Limit)
+	}
+	return g, ctx
+}
+
+// Run is the runBlocking of this package: body launches tasks on a fresh group, and Run waits for all of them.
+// body runs on the calling goroutine outside of Config.Limit, so it can always launch tasks, and it is never restarted.
+// Its error or panic is reported as if it was a task named "body".
+func Run(ctx context.Context, cfg Config, body func(ctx context.Context, g *Group) error) error {
+	g, _ := New(ctx, cfg)
+	g.finish("body", g.call("body", func(ctx context.Context) error {
+		return body(ctx, g)
+	}))
+	return g.Wait()
+}
+
+// Go starts fn as a task named name. It never blocks, so tasks can launch tasks even at the concurrency limit:
+// the new goroutine waits for a free slot, and gives up without running fn if the group is cancelled first.
+func (g *Group) Go(name string, fn func(ctx context.Context) error) {
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		if g.sem != nil {
+			select {
+			case g.sem <- struct{}{}:
+			case <-g.ctx.Done():
+				return
+			}
+			defer func() { <-g.sem }()
+			// select picks at random when a slot frees up as the group is cancelled.
+			if g.ctx.Err() != nil {
+				return
+			}
+		}
+		g.finish(name, g.supervise(name, fn))
+	}()
+}
+
+func (g *Group) supervise(name string, fn func(ctx context.Context) error) error {
+	for restart := uint(0); ; restart++ {
+		err := g.call(name, fn)
+		if err == nil || g.cfg.Policy != Restart {
+			return err
+		}
+		if g.cfg.Backoff.MaxRestarts > 0 && restart >= g.cfg.Backoff.MaxRestarts {
+			if _, ok := err.(*PanicError); ok {
+				return err
+			}
+			return fmt.Errorf("gave up after %d restarts: %w", restart, err)
+		}
+		timer := time.NewTimer(g.cfg.Backoff.delay(restart))
+		select {
+		case <-timer.C:
+		case <-g.ctx.Done():
+			timer.Stop()
+			return err
+		}
+	}
+}
+
+// call runs fn once, returning a panic as a *PanicError.
+func (g *Group) call(name string, fn func(ctx context.Context) error) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = &PanicError{Name: name, Value: p, Stack: debug.Stack()}
+		}
+	}()
+	return fn(g.ctx)
+}
+
+// finish records the final outcome of a task: a *PanicError to be rethrown by Wait, or a failure.
+func (g *Group) finish(name string, err error) {
+	if err == nil {
+		return
+	}
+	if pe, ok := err.(*PanicError); ok {
+		g.mu.Lock()
+		if g.panicked == nil {
+			g.panicked = pe
+		}
+		g.mu.Unlock()
+		g.cancel(pe)
+		return
+	}
+	g.fail(&TaskError{Name: name, Err: err})
+}
+
+func (g *Group) fail(err *TaskError) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.cfg.Policy != CollectAll {
+		if len(g.errs) > 0 {
+			return
+		}
+		g.cancel(err)
+	}
+	g.errs = append(g.errs, err)
+}
+
+// Wait blocks until every task returned, then cancels the group context.
+// If a task panicked, Wait panics with its *PanicError. Otherwise it returns the first failure under FailFast and
+// Restart, or all failures joined under CollectAll.
+func (g *Group) Wait() error {
+	g.wg.Wait()
+	g.cancel(context.Canceled)
+	if g.panicked != nil {
+		panic(g.panicked)
+	}
+	if len(g.errs) == 0 {
+		return nil
+	}
+	if g.cfg.Policy == CollectAll {
+		return errors.Join(g.errs...)
+	}
+	return g.errs[0]
+}
@@ -0,0 +1,234 @@
+package taskgroup
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFailFast(t *testing.T) {
+	errBoom := errors.New("boom")
+	var cancelled atomic.Bool
+	err := Run(context.Background(), Config{Policy: FailFast}, func(ctx context.Context, g *Group) error {
+		g.Go("sleeper", func(ctx context.Context) error {
+			select {
+			case <-ctx.Done():
+				cancelled.Store(true)
+				return ctx.Err()
+			case <-time.After(time.Second):
+				return nil
+			}
+		})
+		g.Go("failer", func(context.Context) error { return errBoom })
+		return nil
+	})
+	var taskErr *TaskError
+	if !errors.Is(err, errBoom) || !errors.As(err, &taskErr) || taskErr.Name != "failer" {
+		t.Errorf("got %v want task %q failing with %v\n", err, "failer", errBoom)
+	}
+	if !cancelled.Load() {
+		t.Errorf("sibling was not cancelled\n")
+	}
+}
+
+func TestCollectAll(t *testing.T) {
+	errA, errB := errors.New("a"), errors.New("b")
+	var finished atomic.Int32
+	g, _ := New(context.Background(), Config{Policy: CollectAll})
+	g.Go("a", func(context.Context) error { return errA })
+	g.Go("b", func(context.Context) error { return errB })
+	g.Go("c", func(ctx context.Context) error {
+		time.Sleep(5 * time.Millisecond)
+		finished.Add(1)
+		return ctx.Err()
+	})
+	err := g.Wait()
+	if !errors.Is(err, errA) || !errors.Is(err, errB) || finished.Load() != 1 {
+		t.Errorf("got %v finished %d want %v and %v finished 1\n", err, finished.Load(), errA, errB)
+	}
+}
+
+func TestRestart(t *testing.T) {
+	errFlaky := errors.New("flaky")
+	var calls atomic.Int32
+	backoff := Backoff{Initial: time.Millisecond, Max: 2 * time.Millisecond, MaxRestarts: 5}
+	g, _ := New(context.Background(), Config{Policy: Restart, Backoff: backoff})
+	g.Go("flaky", func(context.Context) error {
+		if calls.Add(1) < 3 {
+			return errFlaky
+		}
+		return nil
+	})
+	if err := g.Wait(); err != nil || calls.Load() != 3 {
+		t.Errorf("got %v after %d calls want %v after %d calls\n", err, calls.Load(), nil, 3)
+	}
+
+	calls.Store(0)
+	backoff.MaxRestarts = 2
+	g, _ = New(context.Background(), Config{Policy: Restart, Backoff: backoff})
+	g.Go("broken", func(context.Context) error {
+		calls.Add(1)
+		return errFlaky
+	})
+	if err := g.Wait(); !errors.Is(err, errFlaky) || calls.Load() != 3 {
+		t.Errorf("got %v after %d calls want %v after %d calls\n", err, calls.Load(), errFlaky, 3)
+	}
+}
+
+func TestBackoffDelay(t *testing.T) {
+	b := Backoff{Initial: time.Millisecond, Max: 5 * time.Millisecond, Factor: 2}
+	want := []time.Duration{time.Millisecond, 2 * time.Millisecond, 4 * time.Millisecond, 5 * time.Millisecond}
+	for i, w := range want {
+		if got := b.delay(uint(i)); got != w {
+			t.Errorf("delay(%d) got %v want %v\n", i, got, w)
+		}
+	}
+}
+
+func TestPanic(t *testing.T) {
+	var cancelled atomic.Bool
+	defer func() {
+		pe, ok := recover().(*PanicError)
+		if !ok || pe.Name != "bad" || pe.Value != "oops" || !strings.Contains(string(pe.Stack), "taskgroup") {
+			t.Errorf("got %v want panic of task %q\n", pe, "bad")
+		}
+		if !cancelled.Load() {
+			t.Errorf("sibling was not cancelled\n")
+		}
+	}()
+	_ = Run(context.Background(), Config{Policy: CollectAll}, func(ctx context.Context, g *Group) error {
+		g.Go("good", func(ctx context.Context) error {
+			<-ctx.Done()
+			cancelled.Store(true)
+			return nil
+		})
+		g.Go("bad", func(context.Context) error { panic("oops") })
+		return nil
+	})
+	t.Errorf("Run did not panic\n")
+}
+
+func TestLimit(t *testing.T) {
+	var running, peak atomic.Int32
+	g, _ := New(context.Background(), Config{Limit: 2})
+	for range 8 {
+		g.Go("worker", func(context.Context) error {
+			n := running.Add(1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			running.Add(-1)
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil || peak.Load() != 2 {
+		t.Errorf("got %v peak %d want %v peak %d\n", err, peak.Load(), nil, 2)
+	}
+}
+
+func TestRunLimitOne(t *testing.T) {
+	var calls atomic.Int32
+	done := make(chan error, 1)
+	go func() {
+		done <- Run(context.Background(), Config{Limit: 1}, func(ctx context.Context, g *Group) error {
+			for range 3 {
+				g.Go("worker", func(context.Context) error {
+					calls.Add(1)
+					return nil
+				})
+			}
+			return nil
+		})
+	}()
+	select {
+	case err := <-done:
+		if err != nil || calls.Load() != 3 {
+			t.Errorf("got %v after %d calls want %v after %d calls\n", err, calls.Load(), nil, 3)
+		}
+	case <-time.After(time.Second):
+		t.Errorf("Run with Limit 1 deadlocked\n")
+	}
+}
+
+func TestNestedGoLimitOne(t *testing.T) {
+	var calls atomic.Int32
+	done := make(chan error, 1)
+	go func() {
+		g, _ := New(context.Background(), Config{Limit: 1})
+		g.Go("parent", func(context.Context) error {
+			calls.Add(1)
+			g.Go("child", func(context.Context) error {
+				calls.Add(1)
+				return nil
+			})
+			return nil
+		})
+		done <- g.Wait()
+	}()
+	select {
+	case err := <-done:
+		if err != nil || calls.Load() != 2 {
+			t.Errorf("got %v after %d calls want %v after %d calls\n", err, calls.Load(), nil, 2)
+		}
+	case <-time.After(time.Second):
+		t.Errorf("nested Go with Limit 1 deadlocked\n")
+	}
+}
+
+func TestGoCancelledWhileWaiting(t *testing.T) {
+	errBoom := errors.New("boom")
+	var ran atomic.Bool
+	g, _ := New(context.Background(), Config{Limit: 1})
+	started := make(chan struct{})
+	g.Go("failer", func(context.Context) error {
+		close(started)
+		time.Sleep(5 * time.Millisecond)
+		return errBoom
+	})
+	<-started
+	g.Go("waiter", func(context.Context) error {
+		ran.Store(true)
+		return nil
+	})
+	if err := g.Wait(); !errors.Is(err, errBoom) || ran.Load() {
+		t.Errorf("got %v ran %v want %v ran %v\n", err, ran.Load(), errBoom, false)
+	}
+}
+
+func TestRestartPanic(t *testing.T) {
+	var calls atomic.Int32
+	backoff := Backoff{Initial: time.Millisecond, MaxRestarts: 5}
+	g, _ := New(context.Background(), Config{Policy: Restart, Backoff: backoff})
+	g.Go("flaky", func(context.Context) error {
+		if calls.Add(1) < 3 {
+			panic("oops")
+		}
+		return nil
+	})
+	if err := g.Wait(); err != nil || calls.Load() != 3 {
+		t.Errorf("got %v after %d calls want %v after %d calls\n", err, calls.Load(), nil, 3)
+	}
+
+	calls.Store(0)
+	backoff.MaxRestarts = 2
+	defer func() {
+		pe, ok := recover().(*PanicError)
+		if !ok || pe.Name != "broken" || calls.Load() != 3 {
+			t.Errorf("got %v after %d calls want panic of task %q after %d calls\n", pe, calls.Load(), "broken", 3)
+		}
+	}()
+	g, _ = New(context.Background(), Config{Policy: Restart, Backoff: backoff})
+	g.Go("broken", func(context.Context) error {
+		calls.Add(1)
+		panic("oops")
+	})
+	_ = g.Wait()
+	t.Errorf("Wait did not panic\n")
+}